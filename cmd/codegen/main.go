@@ -0,0 +1,336 @@
+// Command codegen regenerates the error codes of the logger package from the CSV export of the
+// product team's spreadsheet: the constants and HTTP statuses (errorcodes.go), the client message
+// catalog (catalog.go) and the JSON export shared with the frontends (errorcodes.json).
+//
+// The CSV has a header row with the columns code, name, category, description, http_status, uz,
+// ru and en, in any order. It is rejected when codes or names are duplicated, when a code lies
+// outside the range of its category (ranges of unknown categories are reserved), when a name does
+// not match its category (Err, Info or Warn prefix), or when a code of the previous JSON export
+// is renumbered or removed.
+//
+// Usage:
+//
+//	go generate ./logger                                       # runs codegen -csv errorcodes.csv
+//	codegen -csv logger/errorcodes.csv -check                  # fails when the files are outdated, for CI
+//	codegen -csv codes.csv -dir logger -allow-removals         # accepts codes removed from the sheet
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// codeRange is the range of codes of a category, mirroring logger.Errorcode.Category.
+type codeRange struct {
+	low, high int    // Codes in [low, high) belong to the category.
+	category  string // Name of the category, see logger.Category.
+	prefix    string // Prefix of the constant names.
+	title     string // Comment of the const block.
+}
+
+// ranges are the ranges of the categories; codes outside of them are reserved.
+var ranges = []codeRange{
+	{1000, 2000, "validation", "Err", "Validation Error Codes"},
+	{2000, 3000, "authentication", "Err", "Authentication Error Codes"},
+	{3000, 4000, "resource", "Err", "Resource Error Codes"},
+	{4000, 5000, "system", "Err", "System Error Codes"},
+	{5000, 6000, "integration", "Err", "Integration Error Codes"},
+	{6000, 7000, "business", "Err", "Business Logic Error Codes"},
+	{7000, 7500, "info", "Info", "Info Logs (7000 - 7499)"},
+	{7500, 8000, "warning", "Warn", "Warning Logs (7500 - 7999)"},
+}
+
+// Code is an error code, as listed in the JSON export.
+type Code struct {
+	Code        int               `json:"code"`
+	Name        string            `json:"name"`
+	Category    string            `json:"category"`
+	Description string            `json:"description"`
+	HTTPStatus  int               `json:"http_status,omitempty"`
+	Messages    map[string]string `json:"messages,omitempty"` // Client messages by language.
+}
+
+func main() {
+	var (
+		csvPath       = flag.String("csv", "", "CSV export of the error codes")
+		dir           = flag.String("dir", "", "directory of the generated files, defaults to the directory of the CSV")
+		check         = flag.Bool("check", false, "fail when the generated files are outdated instead of writing them")
+		allowRemovals = flag.Bool("allow-removals", false, "accept codes of the previous JSON export missing from the CSV")
+	)
+	flag.Parse()
+
+	if *csvPath == "" {
+		fail(errors.New("-csv is required"))
+	}
+	if *dir == "" {
+		*dir = filepath.Dir(*csvPath)
+	}
+
+	f, err := os.Open(*csvPath)
+	if err != nil {
+		fail(err)
+	}
+	codes, err := readCodes(f)
+	f.Close()
+	if err != nil {
+		fail(err)
+	}
+	if err := checkCodes(codes); err != nil {
+		fail(err)
+	}
+	if err := checkExport(filepath.Join(*dir, "errorcodes.json"), codes, *allowRemovals); err != nil {
+		fail(err)
+	}
+
+	files, err := generate(codes)
+	if err != nil {
+		fail(err)
+	}
+
+	outdated := false
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		path := filepath.Join(*dir, name)
+		current, err := os.ReadFile(path)
+		if err == nil && bytes.Equal(current, files[name]) {
+			continue
+		}
+		if *check {
+			fmt.Fprintf(os.Stderr, "codegen: %s is outdated\n", path)
+			outdated = true
+			continue
+		}
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			fail(err)
+		}
+		fmt.Println("wrote", path)
+	}
+	if outdated {
+		os.Exit(1)
+	}
+}
+
+// readCodes parses the CSV, sorting the codes.
+func readCodes(r io.Reader) ([]Code, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("CSV is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"code", "name", "category"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV has no %q column", name)
+		}
+	}
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var codes []Code
+	for line, row := range rows[1:] {
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+		code, err := strconv.Atoi(field(row, "code"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid code %q", line+2, field(row, "code"))
+		}
+		c := Code{
+			Code:        code,
+			Name:        field(row, "name"),
+			Category:    strings.ToLower(field(row, "category")),
+			Description: field(row, "description"),
+		}
+		if status := field(row, "http_status"); status != "" {
+			if c.HTTPStatus, err = strconv.Atoi(status); err != nil || c.HTTPStatus < 100 || c.HTTPStatus > 599 {
+				return nil, fmt.Errorf("line %d: invalid http_status %q", line+2, status)
+			}
+		}
+		for _, lang := range []string{"uz", "ru", "en"} {
+			if message := field(row, lang); message != "" {
+				if c.Messages == nil {
+					c.Messages = make(map[string]string)
+				}
+				c.Messages[lang] = message
+			}
+		}
+		codes = append(codes, c)
+	}
+	slices.SortFunc(codes, func(a, b Code) int { return a.Code - b.Code })
+	return codes, nil
+}
+
+// checkCodes detects duplicated codes and names, codes in reserved ranges or in the range of
+// another category, invalid names and incomplete translations.
+func checkCodes(codes []Code) error {
+	var errs []error
+	names := make(map[string]int)
+	for i, c := range codes {
+		if i > 0 && codes[i-1].Code == c.Code {
+			errs = append(errs, fmt.Errorf("code %d: duplicated (%s and %s)", c.Code, codes[i-1].Name, c.Name))
+		}
+		if other, ok := names[c.Name]; ok {
+			errs = append(errs, fmt.Errorf("code %d: name %s already used by code %d", c.Code, c.Name, other))
+		}
+		names[c.Name] = c.Code
+
+		r, ok := rangeOf(c.Code)
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("code %d: in a reserved range", c.Code))
+		case r.category != c.Category:
+			errs = append(errs, fmt.Errorf("code %d: category %q, but the code is in the %s range %d-%d", c.Code, c.Category, r.category, r.low, r.high-1))
+		case !token.IsIdentifier(c.Name) || !token.IsExported(c.Name) || !strings.HasPrefix(c.Name, r.prefix):
+			errs = append(errs, fmt.Errorf("code %d: name %q must be an exported identifier starting with %s", c.Code, c.Name, r.prefix))
+		}
+		if len(c.Messages) > 0 && (c.Messages["uz"] == "" || c.Messages["ru"] == "") {
+			errs = append(errs, fmt.Errorf("code %d: both the uz and ru messages are required", c.Code))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkExport compares the codes with the previous JSON export, if any: published codes must
+// keep their number, and may only be removed with allowRemovals.
+func checkExport(path string, codes []Code, allowRemovals bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var previous []Code
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	current := make(map[string]int, len(codes))
+	for _, c := range codes {
+		current[c.Name] = c.Code
+	}
+	var errs []error
+	for _, p := range previous {
+		code, ok := current[p.Name]
+		switch {
+		case !ok && !allowRemovals:
+			errs = append(errs, fmt.Errorf("code %d: %s was removed, pass -allow-removals to accept it", p.Code, p.Name))
+		case ok && code != p.Code:
+			errs = append(errs, fmt.Errorf("code %d: %s was renumbered to %d", p.Code, p.Name, code))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// rangeOf returns the range of the code.
+func rangeOf(code int) (codeRange, bool) {
+	for _, r := range ranges {
+		if code >= r.low && code < r.high {
+			return r, true
+		}
+	}
+	return codeRange{}, false
+}
+
+// generate returns the content of the generated files by name.
+func generate(codes []Code) (map[string][]byte, error) {
+	var consts, statuses, catalog bytes.Buffer
+	consts.WriteString("// Code generated by codegen from errorcodes.csv. DO NOT EDIT.\n\npackage logger\n\ntype Errorcode int\n")
+	statuses.WriteString("\n// httpStatuses maps the error codes to the HTTP status of the response they usually come with.\nvar httpStatuses = map[Errorcode]int{\n")
+	catalog.WriteString("// Code generated by codegen from errorcodes.csv. DO NOT EDIT.\n\npackage logger\n\n// clientMessages is the catalog of the client messages of the error codes.\nvar clientMessages = map[Errorcode]ClientMessage{\n")
+
+	statusGroup, catalogGroup := false, false
+	for _, r := range ranges {
+		var inRange []Code
+		for _, c := range codes {
+			if c.Code >= r.low && c.Code < r.high {
+				inRange = append(inRange, c)
+			}
+		}
+		if len(inRange) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&consts, "\n// %s\nconst (\n", r.title)
+		separated := false
+		for _, c := range inRange {
+			fmt.Fprintf(&consts, "\t// %d: %s\n\t%s Errorcode = %d\n", c.Code, c.Description, c.Name, c.Code)
+
+			if c.HTTPStatus != 0 {
+				if statusGroup && !separated {
+					statuses.WriteString("\n")
+				}
+				fmt.Fprintf(&statuses, "\t%s: %d,\n", c.Name, c.HTTPStatus)
+				separated = true
+			}
+		}
+		consts.WriteString(")\n")
+		statusGroup = statusGroup || separated
+
+		separated = false
+		for _, c := range inRange {
+			if len(c.Messages) == 0 {
+				continue
+			}
+			if catalogGroup && !separated {
+				catalog.WriteString("\n")
+			}
+			fmt.Fprintf(&catalog, "\t%s: {Uz: %s, Ru: %s, En: %s},\n", c.Name,
+				strconv.Quote(c.Messages["uz"]), strconv.Quote(c.Messages["ru"]), strconv.Quote(c.Messages["en"]))
+			separated = true
+		}
+		catalogGroup = catalogGroup || separated
+	}
+	statuses.WriteString("}\n")
+	catalog.WriteString("}\n")
+	consts.Write(statuses.Bytes())
+
+	files := make(map[string][]byte)
+	for name, buf := range map[string]*bytes.Buffer{"errorcodes.go": &consts, "catalog.go": &catalog} {
+		source, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %w", name, err)
+		}
+		files[name] = source
+	}
+
+	var export bytes.Buffer
+	encoder := json.NewEncoder(&export)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(codes); err != nil {
+		return nil, fmt.Errorf("failed to encode the JSON export: %w", err)
+	}
+	files["errorcodes.json"] = export.Bytes()
+	return files, nil
+}
+
+// fail prints the error and exits.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "codegen:", err)
+	os.Exit(1)
+}
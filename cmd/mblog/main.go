@@ -0,0 +1,40 @@
+// Command mblog is the operator tool for the log pipeline.
+//
+// Usage:
+//
+//	mblog tail -queue logs -level error -code 4003
+//	mblog tail -exchange logs.fanout -json
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "tail":
+		tail(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+// usage prints the available commands and exits.
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: mblog <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  tail    live-stream and pretty-print logs")
+	os.Exit(2)
+}
+
+// fail prints the error and exits.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "mblog:", err)
+	os.Exit(1)
+}
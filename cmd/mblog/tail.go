@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// firehoseExchange is the RabbitMQ firehose, receiving a copy of every published message
+// once tracing is enabled with `rabbitmqctl trace_on`.
+const firehoseExchange = "amq.rabbitmq.trace"
+
+// tailFilter selects the printed logs. Zero fields match everything.
+type tailFilter struct {
+	minLevel logger.Level
+	code     int
+	merchant string
+	endpoint string
+}
+
+// tail binds a temporary queue to the log exchange and prints matching logs until interrupted.
+//
+// Logs published to a queue through the default exchange can only be observed through the firehose,
+// so by default the temporary queue is bound to amq.rabbitmq.trace and only copies of messages routed
+// to -queue are printed. Pass -exchange when logs are published to a fanout or topic exchange.
+// The log queue itself is never consumed from, so tailing does not steal logs from the consumers.
+func tail(args []string) {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	var (
+		url        = fs.String("url", os.Getenv("AMQP_URL"), "AMQP connection URL")
+		queue      = fs.String("queue", "logs", "log queue to follow when tailing through the firehose")
+		exchange   = fs.String("exchange", firehoseExchange, "exchange the temporary queue is bound to")
+		routingKey = fs.String("routing-key", "#", "binding key used for topic exchanges other than the firehose")
+		level      = fs.String("level", "", "minimal level to print: info, warning, error or critical")
+		code       = fs.Int("code", 0, "only print logs with this error code")
+		merchant   = fs.String("merchant", "", "only print logs of this merchant API key")
+		endpoint   = fs.String("endpoint", "", "only print logs whose API endpoint contains this text")
+		asJSON     = fs.Bool("json", false, "print the raw JSON of every log instead of a summary line")
+	)
+	fs.Parse(args)
+
+	filter := tailFilter{code: *code, merchant: *merchant, endpoint: *endpoint}
+	if *level != "" {
+		l, err := logger.ParseLevel(*level)
+		if err != nil {
+			fail(err)
+		}
+		filter.minLevel = l
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	conn, err := amqp.Dial(*url)
+	if err != nil {
+		fail(fmt.Errorf("failed to connect: %w", err))
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		fail(fmt.Errorf("failed to open channel: %w", err))
+	}
+
+	// An exclusive, auto-deleted queue disappears as soon as the tail stops.
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		fail(fmt.Errorf("failed to declare temporary queue: %w", err))
+	}
+
+	key := *routingKey
+	if *exchange == firehoseExchange {
+		key = "publish.#"
+	}
+	if err := ch.QueueBind(q.Name, key, *exchange, false, nil); err != nil {
+		fail(fmt.Errorf("failed to bind to %s: %w", *exchange, err))
+	}
+
+	deliveries, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		fail(fmt.Errorf("failed to consume: %w", err))
+	}
+
+	fmt.Fprintf(os.Stderr, "tailing %s (ctrl-c to stop)\n", *exchange)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-deliveries:
+			if !ok {
+				fail(fmt.Errorf("connection closed"))
+			}
+			if *exchange == firehoseExchange && !routedTo(msg.Headers, *queue) {
+				continue
+			}
+
+			d, err := consumers.Decode(msg.Body)
+			if err != nil || !filter.match(d.Record) {
+				continue
+			}
+			if *asJSON {
+				os.Stdout.Write(append(d.Body, '\n'))
+			} else {
+				printLog(os.Stdout, d.Record)
+			}
+		}
+	}
+}
+
+// routedTo reports whether a firehose message was published to the queue through the default exchange.
+func routedTo(headers amqp.Table, queue string) bool {
+	if exchange, _ := headers["exchange_name"].(string); exchange != "" {
+		return false
+	}
+	keys, _ := headers["routing_keys"].([]any)
+	return slices.Contains(keys, any(queue))
+}
+
+// match reports whether the log passes the filter.
+func (f tailFilter) match(r consumers.Record) bool {
+	if level, err := logger.ParseLevel(r.ErrorLevel); err == nil && level < f.minLevel {
+		return false
+	}
+	if f.code != 0 && r.Errorcode != f.code {
+		return false
+	}
+	if f.merchant != "" && r.MerchantApiKey != f.merchant {
+		return false
+	}
+	if f.endpoint != "" && !strings.Contains(r.ApiEndpoint, f.endpoint) {
+		return false
+	}
+	return true
+}
+
+// levelColors are the ANSI colors of the level names.
+var levelColors = map[string]string{
+	"info":     "\033[36m",
+	"warning":  "\033[33m",
+	"error":    "\033[31m",
+	"critical": "\033[1;41;97m",
+}
+
+// printLog writes a colored summary line of the log, followed by its details and payload when present.
+func printLog(w io.Writer, r consumers.Record) {
+	fmt.Fprintf(w, "%s %s%-8s\033[0m %d %d %s %s [%s]\n",
+		r.Timestamp.Local().Format(time.TimeOnly), levelColors[r.ErrorLevel], r.ErrorLevel,
+		r.Errorcode, r.StatusCode, strings.TrimSpace(r.Method+" "+r.ApiEndpoint), r.ClientMessageUz, r.FunctionName)
+
+	if r.ErrorMessage != "" {
+		fmt.Fprintf(w, "    error:   %s\n", r.ErrorMessage)
+	}
+	if r.RequestPayload != "" && r.RequestPayload != "null" {
+		fmt.Fprintf(w, "    payload: %s\n", compactJSON(r.RequestPayload))
+	}
+}
+
+// compactJSON removes insignificant whitespace from JSON payloads; other text is returned unchanged.
+func compactJSON(s string) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		return s
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+-- Log table for analytics. ReplacingMergeTree collapses redelivered messages with the same id.
+CREATE TABLE IF NOT EXISTS logs (
+    id                String,
+    timestamp         DateTime64(3, 'UTC'),
+    error_level       LowCardinality(String),
+    error_code        UInt16,
+    client_message_uz String,
+    client_message_ru String,
+    error_message     String,
+    details_uz        String,
+    details_ru        String,
+    api_endpoint      LowCardinality(String),
+    method            LowCardinality(String),
+    function_name     LowCardinality(String),
+    status_code       UInt16,
+    request_payload   String CODEC(ZSTD(3)),
+    event_type        LowCardinality(String),
+    response_data     String CODEC(ZSTD(3)),
+    merchant_api_key  String,
+    group_id          String DEFAULT '',
+    user_id           String DEFAULT '',
+    session_id        String DEFAULT '',
+    client_ip         String DEFAULT '',
+    user_agent        String DEFAULT '',
+    request_id        String DEFAULT '',
+    environment       LowCardinality(String) DEFAULT '',
+    duration_ms       Int64 DEFAULT 0,
+    received_at       DateTime64(3, 'UTC') DEFAULT now64(3),
+    -- Skips the granules without the group when reassembling the logs of an operation.
+    INDEX group_id_idx group_id TYPE bloom_filter GRANULARITY 4,
+    -- Skips the granules without the user or session when support looks up a customer.
+    INDEX user_id_idx user_id TYPE bloom_filter GRANULARITY 4,
+    INDEX session_id_idx session_id TYPE bloom_filter GRANULARITY 4,
+    -- Skips the granules without the client or request in abuse investigations and traces.
+    INDEX client_ip_idx client_ip TYPE bloom_filter GRANULARITY 4,
+    INDEX request_id_idx request_id TYPE bloom_filter GRANULARITY 4
+)
+ENGINE = ReplacingMergeTree(received_at)
+PARTITION BY toYYYYMM(timestamp)
+ORDER BY (error_level, error_code, timestamp, id);
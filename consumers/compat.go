@@ -0,0 +1,81 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"golang.org/x/mod/semver"
+)
+
+// ErrIncompatibleProducer is reported for logs of producers older than the compatibility
+// requirements, see CheckCompatibility.
+var ErrIncompatibleProducer = errors.New("incompatible log producer")
+
+// Compatibility holds the minimal producer versions accepted by a consumer.
+type Compatibility struct {
+	// MinLoggerVersion is the minimal version of the logger module, e.g. "v1.8.0". Producers
+	// not reporting a version (unreleased builds, producers publishing without headers) pass.
+	MinLoggerVersion string
+
+	// MinSchemaVersion is the minimal log schema version, taken from the capability header or,
+	// without it, from the log itself.
+	MinSchemaVersion int
+}
+
+// Producer is the capability metadata of the producer of a message, see logger.HeaderProducerVersion.
+type Producer struct {
+	LoggerVersion string // Version of the logger module, empty if not reported.
+	SchemaVersion int    // Version of the log schema.
+	Encoding      string // Wire format of the body, empty if not reported.
+}
+
+// ProducerOf returns the capability metadata of the producer of the delivery. The RabbitMQ
+// transport of the logger cannot set headers, so logs it published only report the schema
+// version of their body.
+func ProducerOf(d Delivery) Producer {
+	p := Producer{
+		LoggerVersion: d.Headers[logger.HeaderProducerVersion],
+		Encoding:      d.Headers[logger.HeaderEncoding],
+		SchemaVersion: d.Record.SchemaVersion,
+	}
+	if version, err := strconv.Atoi(d.Headers[logger.HeaderSchemaVersion]); err == nil {
+		p.SchemaVersion = version
+	}
+	return p
+}
+
+// Check returns an error wrapping ErrIncompatibleProducer when the producer does not meet the
+// requirements.
+func (c Compatibility) Check(p Producer) error {
+	if c.MinLoggerVersion != "" && semver.IsValid(p.LoggerVersion) && semver.Compare(p.LoggerVersion, c.MinLoggerVersion) < 0 {
+		return fmt.Errorf("%w: logger %s is older than %s", ErrIncompatibleProducer, p.LoggerVersion, c.MinLoggerVersion)
+	}
+	if p.SchemaVersion < c.MinSchemaVersion {
+		return fmt.Errorf("%w: schema version %d is older than %d", ErrIncompatibleProducer, p.SchemaVersion, c.MinSchemaVersion)
+	}
+	return nil
+}
+
+// CheckCompatibility returns a batch handler reporting the logs of producers not meeting the
+// requirements to report, e.g. to list the services to upgrade before a breaking schema change.
+// All logs are still passed to next.
+//
+// Usage:
+//
+//	compat := consumers.Compatibility{MinLoggerVersion: "v1.8.0", MinSchemaVersion: 1}
+//	handler := consumers.CheckCompatibility(writer.Write, compat, func(d consumers.Delivery, err error) {
+//		slog.Warn("outdated log producer", "function", d.Record.FunctionName, "error", err)
+//	})
+func CheckCompatibility(next BatchHandler, config Compatibility, report func(d Delivery, err error)) BatchHandler {
+	return func(ctx context.Context, batch []Delivery) error {
+		for _, d := range batch {
+			if err := config.Check(ProducerOf(d)); err != nil {
+				report(d, err)
+			}
+		}
+		return next(ctx, batch)
+	}
+}
@@ -0,0 +1,7 @@
+package consumers
+
+// Decompress restores the payload fields compressed by the producer (see logger.WithPayloadCompression)
+// and clears their encodings. Decode calls it for every record.
+func Decompress(record *Record) error {
+	return record.Decompress()
+}
@@ -0,0 +1,281 @@
+package consumers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Config holds the settings shared by all log queue consumers.
+type Config struct {
+	URL           string        // AMQP connection URL.
+	Queue         string        // Name of the log queue to consume.
+	Environment   string        // Environment prefix of the queue, see logger.NamespacedQueue; optional.
+	Tenant        string        // Tenant prefix of the queue, see logger.NamespacedQueue; optional.
+	BatchSize     int           // Maximum number of logs handed to the handler at once. Defaults to 100.
+	FlushInterval time.Duration // Maximum time a log waits for its batch to fill up. Defaults to 1s.
+	MaxRetries    int           // Retries of a failed batch before it is given up. Defaults to 3.
+	RetryBackoff  time.Duration // Delay before the first retry, doubled on every retry. Defaults to 1s.
+
+	// DeadLetter rejects batches that still fail after the retries, so they are routed to the queue's
+	// dead-letter exchange (or dropped if it has none). By default failed batches are requeued.
+	DeadLetter bool
+
+	// Credentials fetches the user and password on every (re)connection, replacing the ones of
+	// the URL, so rotated secrets are picked up without a restart; optional.
+	Credentials logger.CredentialProvider
+
+	// OnError is called with connection and handler errors; optional.
+	OnError func(err error)
+
+	// Decode converts message bodies into deliveries; messages it fails on are rejected without requeueing.
+	// Defaults to Decode, which expects log messages. Consumers of other queues (e.g. orders) use DecodeRaw.
+	Decode func(body []byte) (Delivery, error)
+}
+
+// Delivery is a single decoded message taken from the queue.
+type Delivery struct {
+	ID     string // Stable identifier of the message: its AMQP message ID if set, otherwise derived from its body.
+	Record Record // Decoded log; empty for messages decoded with DecodeRaw.
+	Body   []byte // Raw message body.
+
+	// Headers holds the string headers of the message, e.g. the capability headers of the
+	// producer (see ProducerOf).
+	Headers map[string]string
+}
+
+// BatchHandler processes a batch of logs. Returning an error retries the whole batch.
+type BatchHandler func(ctx context.Context, batch []Delivery) error
+
+// Consumer consumes the log queue in batches, acknowledging messages only after the handler succeeded.
+type Consumer struct {
+	config Config // Consumer settings with defaults applied.
+}
+
+// New initializes and returns a new Consumer instance.
+func New(config Config) *Consumer {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = time.Second
+	}
+	if config.Decode == nil {
+		config.Decode = Decode
+	}
+	config.Queue = logger.NamespacedQueue(config.Environment, config.Tenant, config.Queue)
+
+	return &Consumer{config: config}
+}
+
+// Run consumes the queue and hands batches to the handler until the context is cancelled.
+// Lost connections are re-established automatically.
+func (c *Consumer) Run(ctx context.Context, handler BatchHandler) error {
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+		err := c.consume(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		c.reportError(err)
+
+		// A session that ran for a while resets the reconnection backoff.
+		if time.Since(started) > time.Minute {
+			attempt = 1
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Duration(min(attempt, 30)) * time.Second):
+		}
+	}
+}
+
+// consume runs a single consuming session until the connection is lost or the context is cancelled.
+func (c *Consumer) consume(ctx context.Context, handler BatchHandler) error {
+	url, err := logger.URLWithCredentials(ctx, c.config.URL, c.config.Credentials)
+	if err != nil {
+		return err
+	}
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+
+	// Declared with the same settings as the logger, so either side can start first.
+	if _, err := ch.QueueDeclare(c.config.Queue, true, true, false, false, amqp.Table{}); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.Qos(c.config.BatchSize, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	deliveries, err := ch.Consume(c.config.Queue, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming messages: %w", err)
+	}
+
+	ticker := time.NewTicker(c.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]amqp.Delivery, 0, c.config.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush(context.WithoutCancel(ctx), handler, batch)
+			return nil
+		case d, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("message channel closed")
+			}
+			batch = append(batch, d)
+			if len(batch) >= c.config.BatchSize {
+				c.flush(ctx, handler, batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			c.flush(ctx, handler, batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// flush decodes the batch, hands it to the handler and settles the messages.
+// Messages that cannot be decoded are rejected without requeueing.
+func (c *Consumer) flush(ctx context.Context, handler BatchHandler, batch []amqp.Delivery) {
+	if len(batch) == 0 {
+		return
+	}
+
+	items := make([]Delivery, 0, len(batch))
+	accepted := make([]amqp.Delivery, 0, len(batch))
+	for _, d := range batch {
+		item, err := c.config.Decode(d.Body)
+		if err != nil {
+			d.Reject(false)
+			continue
+		}
+		if d.MessageId != "" {
+			item.ID = d.MessageId
+		}
+		item.Headers = stringHeaders(d.Headers)
+		items = append(items, item)
+		accepted = append(accepted, d)
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	err := c.handle(ctx, handler, items)
+	if err != nil {
+		c.reportError(fmt.Errorf("failed to handle batch of %d logs: %w", len(items), err))
+	}
+	for _, d := range accepted {
+		if err == nil {
+			d.Ack(false)
+		} else {
+			d.Nack(false, !c.config.DeadLetter)
+		}
+	}
+}
+
+// handle calls the handler, retrying with exponential backoff.
+func (c *Consumer) handle(ctx context.Context, handler BatchHandler, items []Delivery) error {
+	backoff := c.config.RetryBackoff
+	err := handler(ctx, items)
+	for retry := 0; err != nil && retry < c.config.MaxRetries; retry++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		err = handler(ctx, items)
+	}
+	return err
+}
+
+// stringHeaders returns the string headers of the table, nil if it has none.
+func stringHeaders(table amqp.Table) map[string]string {
+	var headers map[string]string
+	for key, value := range table {
+		if s, ok := value.(string); ok {
+			if headers == nil {
+				headers = make(map[string]string, len(table))
+			}
+			headers[key] = s
+		}
+	}
+	return headers
+}
+
+// reportError passes the error to the OnError callback, if any.
+func (c *Consumer) reportError(err error) {
+	if err != nil && c.config.OnError != nil {
+		c.config.OnError(err)
+	}
+}
+
+// Decode decodes a raw log message into a Delivery. Messages of older producers are
+// converted into the current schema with DefaultNormalizer first.
+// MessagePack and protobuf messages are detected and decoded with DecodeMsgPack and DecodeProto.
+func Decode(body []byte) (Delivery, error) {
+	if IsMsgPack(body) {
+		return DecodeMsgPack(body)
+	}
+	if IsProto(body) {
+		return DecodeProto(body)
+	}
+	return decodeJSON(body)
+}
+
+// decodeJSON decodes a JSON log message into a Delivery.
+func decodeJSON(body []byte) (Delivery, error) {
+	normalized, err := DefaultNormalizer.Normalize(body)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("failed to normalize log: %w", err)
+	}
+
+	var record Record
+	if err := json.Unmarshal(normalized, &record); err != nil {
+		return Delivery{}, fmt.Errorf("failed to decode log: %w", err)
+	}
+	if err := Decompress(&record); err != nil {
+		return Delivery{}, err
+	}
+
+	d, _ := DecodeRaw(body)
+	d.Record = record
+	return d, nil
+}
+
+// DecodeRaw wraps a message body of any kind into a Delivery without decoding it.
+func DecodeRaw(body []byte) (Delivery, error) {
+	sum := sha256.Sum256(body)
+	return Delivery{
+		ID:   hex.EncodeToString(sum[:]),
+		Body: body,
+	}, nil
+}
@@ -0,0 +1,184 @@
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/redis/go-redis/v9"
+)
+
+// Store remembers the IDs of the messages that were already handled.
+type Store interface {
+	// Seen returns the subset of the IDs that were already handled.
+	Seen(ctx context.Context, ids []string) (map[string]bool, error)
+
+	// Mark records the IDs as handled.
+	Mark(ctx context.Context, ids []string) error
+}
+
+// Wrap returns a batch handler dropping the messages already handled (producer retries,
+// redeliveries after a consumer crash) before they reach next.
+// Messages are only marked as handled after next succeeded, so a failed batch is retried in full.
+//
+// Usage:
+//
+//	handler := dedup.Wrap(dedup.NewLRU(100_000, time.Hour), postgres.NewWriter(db).Write)
+//	err := consumers.New(consumerConfig).Run(ctx, handler)
+func Wrap(store Store, next consumers.BatchHandler) consumers.BatchHandler {
+	return func(ctx context.Context, batch []consumers.Delivery) error {
+		ids := make([]string, 0, len(batch))
+		for _, d := range batch {
+			ids = append(ids, d.ID)
+		}
+
+		seen, err := store.Seen(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		fresh := make([]consumers.Delivery, 0, len(batch))
+		freshIDs := make([]string, 0, len(batch))
+		for _, d := range batch {
+			if seen[d.ID] {
+				continue
+			}
+			// Duplicates within the batch are dropped as well.
+			seen[d.ID] = true
+			fresh = append(fresh, d)
+			freshIDs = append(freshIDs, d.ID)
+		}
+
+		if len(fresh) == 0 {
+			return nil
+		}
+		if err := next(ctx, fresh); err != nil {
+			return err
+		}
+
+		// The batch is already handled: failing here would retry it and create the duplicates
+		// the stage is meant to prevent, so a failed mark only weakens later deduplication.
+		store.Mark(ctx, freshIDs)
+		return nil
+	}
+}
+
+// lruStore is the in-memory Store implementation.
+type lruStore struct {
+	capacity int                      // Maximum number of remembered IDs.
+	ttl      time.Duration            // How long an ID is remembered; zero remembers it until evicted.
+	mu       sync.Mutex               // Protects order and entries.
+	order    *list.List               // Entries from the most to the least recently marked.
+	entries  map[string]*list.Element // Entries by ID.
+}
+
+// lruEntry is a remembered ID.
+type lruEntry struct {
+	id     string
+	marked time.Time
+}
+
+// NewLRU returns an in-memory Store remembering up to capacity IDs for ttl (zero keeps them until evicted).
+// It only deduplicates within a single consumer process; use NewRedis for consumers running in several replicas.
+func NewLRU(capacity int, ttl time.Duration) Store {
+	if capacity <= 0 {
+		capacity = 100_000
+	}
+	return &lruStore{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Seen returns the IDs that are remembered and not expired.
+func (s *lruStore) Seen(ctx context.Context, ids []string) (map[string]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		el, ok := s.entries[id]
+		if !ok {
+			continue
+		}
+		if s.ttl > 0 && time.Since(el.Value.(*lruEntry).marked) > s.ttl {
+			s.order.Remove(el)
+			delete(s.entries, id)
+			continue
+		}
+		seen[id] = true
+	}
+	return seen, nil
+}
+
+// Mark remembers the IDs, evicting the least recently marked ones above the capacity.
+func (s *lruStore) Mark(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, id := range ids {
+		if el, ok := s.entries[id]; ok {
+			el.Value.(*lruEntry).marked = now
+			s.order.MoveToFront(el)
+			continue
+		}
+		s.entries[id] = s.order.PushFront(&lruEntry{id: id, marked: now})
+	}
+
+	for s.order.Len() > s.capacity {
+		el := s.order.Back()
+		s.order.Remove(el)
+		delete(s.entries, el.Value.(*lruEntry).id)
+	}
+	return nil
+}
+
+// redisStore is the Store implementation shared by all consumer replicas through Redis.
+type redisStore struct {
+	client redis.UniversalClient // Redis client.
+	prefix string                // Prefix of the keys.
+	ttl    time.Duration         // How long an ID is remembered.
+}
+
+// NewRedis returns a Store keeping the IDs in Redis under prefix+id for ttl (defaults to 24 hours).
+func NewRedis(client redis.UniversalClient, prefix string, ttl time.Duration) Store {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &redisStore{client: client, prefix: prefix, ttl: ttl}
+}
+
+// Seen checks the existence of the ID keys in a single pipeline.
+func (s *redisStore) Seen(ctx context.Context, ids []string) (map[string]bool, error) {
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.Exists(ctx, s.prefix+id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for i, cmd := range cmds {
+		if cmd.Val() > 0 {
+			seen[ids[i]] = true
+		}
+	}
+	return seen, nil
+}
+
+// Mark sets the ID keys with the TTL in a single pipeline.
+func (s *redisStore) Mark(ctx context.Context, ids []string) error {
+	pipe := s.client.Pipeline()
+	for _, id := range ids {
+		pipe.Set(ctx, s.prefix+id, 1, s.ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,57 @@
+package consumers
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// FilterEnvironments returns a batch handler passing only the logs of the given environments
+// to next, e.g. to keep staging logs out of production stores reading a shared queue.
+// The other logs are acknowledged without being handled. Logs of producers not reporting
+// an environment only pass when "" is among the environments.
+//
+// Usage:
+//
+//	handler := consumers.FilterEnvironments(postgres.NewWriter(db).Write, "production")
+//	err := consumers.New(consumerConfig).Run(ctx, handler)
+func FilterEnvironments(next BatchHandler, environments ...string) BatchHandler {
+	return func(ctx context.Context, batch []Delivery) error {
+		kept := make([]Delivery, 0, len(batch))
+		for _, d := range batch {
+			if slices.Contains(environments, d.Record.Environment) {
+				kept = append(kept, d)
+			}
+		}
+
+		if len(kept) == 0 {
+			return nil
+		}
+		return next(ctx, kept)
+	}
+}
+
+// DropExpired returns a batch handler acknowledging logs past their `expires_at` time without
+// passing them to next, e.g. transient availability warnings consumed after a backlog.
+// Logs without an expiry always pass.
+//
+// Usage:
+//
+//	handler := consumers.DropExpired(forwarder.Forward)
+//	err := consumers.New(consumerConfig).Run(ctx, handler)
+func DropExpired(next BatchHandler) BatchHandler {
+	return func(ctx context.Context, batch []Delivery) error {
+		now := time.Now()
+		kept := make([]Delivery, 0, len(batch))
+		for _, d := range batch {
+			if !d.Record.Expired(now) {
+				kept = append(kept, d)
+			}
+		}
+
+		if len(kept) == 0 {
+			return nil
+		}
+		return next(ctx, kept)
+	}
+}
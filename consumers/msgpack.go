@@ -0,0 +1,52 @@
+package consumers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// IsMsgPack reports whether the body looks like a MessagePack encoded log (a map) rather than JSON.
+// JSON objects start with '{' or whitespace, which are never MessagePack map headers.
+func IsMsgPack(body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+	c := body[0]
+	return c >= 0x80 && c <= 0x8f || c == 0xde || c == 0xdf
+}
+
+// MsgPackToJSON converts a MessagePack encoded message into the equivalent JSON, e.g. to
+// store it or print it. Timestamps are written in RFC 3339 like the JSON encoding of the logger.
+func MsgPackToJSON(body []byte) ([]byte, error) {
+	decoder := msgpack.NewDecoder(bytes.NewReader(body))
+	decoder.SetMapDecoder(func(d *msgpack.Decoder) (any, error) {
+		return d.DecodeUntypedMap()
+	})
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// DecodeMsgPack decodes a MessagePack encoded log message into a Delivery.
+// The delivery body is converted to JSON, so handlers storing raw bodies work with both encodings.
+func DecodeMsgPack(body []byte) (Delivery, error) {
+	converted, err := MsgPackToJSON(body)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("failed to decode msgpack log: %w", err)
+	}
+
+	d, err := decodeJSON(converted)
+	if err != nil {
+		return Delivery{}, err
+	}
+	// The ID is derived from the original body, like for any other message.
+	id, _ := DecodeRaw(body)
+	d.ID = id.ID
+	return d, nil
+}
@@ -0,0 +1,193 @@
+package consumers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Normalizer converts messages written by older producers into the current log schema:
+// renamed fields are mapped to their current names, missing fields get defaults and
+// values of outdated types are converted.
+type Normalizer struct {
+	renames    map[string]string          // Old field name to current field name.
+	defaults   map[string]json.RawMessage // Values of fields missing from the message.
+	transforms []func(map[string]json.RawMessage) error
+}
+
+// DefaultNormalizer is the normalizer used by Decode. It understands camelCase field names
+// and the value types used by earlier logger versions.
+var DefaultNormalizer = NewNormalizer()
+
+// NewNormalizer returns a normalizer with the built-in renames and conversions.
+func NewNormalizer() *Normalizer {
+	n := &Normalizer{
+		renames:  make(map[string]string),
+		defaults: make(map[string]json.RawMessage),
+	}
+
+	for old, current := range map[string]string{
+		"errorLevel":      "error_level",
+		"level":           "error_level",
+		"errorCode":       "error_code",
+		"code":            "error_code",
+		"clientMessageUz": "client_message_uz",
+		"clientMessageRu": "client_message_ru",
+		"errorMessage":    "error_message",
+		"detailsUz":       "details_uz",
+		"detailsRu":       "details_ru",
+		"apiEndpoint":     "api_endpoint",
+		"endpoint":        "api_endpoint",
+		"functionName":    "function_name",
+		"statusCode":      "status_code",
+		"requestPayload":  "request_payload",
+		"payload":         "request_payload",
+		"eventType":       "event_type",
+		"responseData":    "response_data",
+		"merchantApiKey":  "merchant_api_key",
+		"durationMs":      "duration_ms",
+		"time":            "timestamp",
+	} {
+		n.Rename(old, current)
+	}
+
+	n.Default("error_level", "info")
+	n.Transform(numberField("error_code"))
+	n.Transform(numberField("status_code"))
+	n.Transform(stringField("request_payload"))
+	n.Transform(stringField("response_data"))
+	n.Transform(timestampField("timestamp"))
+	return n
+}
+
+// Rename maps an old field name to its current name. The old value is only used when
+// the message does not carry the current field as well.
+func (n *Normalizer) Rename(old, current string) *Normalizer {
+	n.renames[old] = current
+	return n
+}
+
+// Default sets the value of a field missing from the message.
+// It panics if the value cannot be encoded as JSON.
+func (n *Normalizer) Default(field string, value any) *Normalizer {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("consumers: invalid default for %s: %v", field, err))
+	}
+	n.defaults[field] = raw
+	return n
+}
+
+// Transform adds a conversion run on the message fields after renames and defaults.
+func (n *Normalizer) Transform(fn func(fields map[string]json.RawMessage) error) *Normalizer {
+	n.transforms = append(n.transforms, fn)
+	return n
+}
+
+// Normalize returns the message converted into the current schema.
+func (n *Normalizer) Normalize(body []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	for old, current := range n.renames {
+		value, ok := fields[old]
+		if !ok {
+			continue
+		}
+		delete(fields, old)
+		if _, exists := fields[current]; !exists {
+			fields[current] = value
+		}
+	}
+
+	for field, value := range n.defaults {
+		if _, ok := fields[field]; !ok {
+			fields[field] = value
+		}
+	}
+
+	for _, transform := range n.transforms {
+		if err := transform(fields); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// numberField converts a quoted number (e.g. "4003") into a JSON number.
+func numberField(name string) func(map[string]json.RawMessage) error {
+	return func(fields map[string]json.RawMessage) error {
+		value, ok := fields[name]
+		if !ok || !isJSONString(value) {
+			return nil
+		}
+
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return err
+		}
+		number, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("field %s: %q is not a number", name, s)
+		}
+		fields[name] = json.RawMessage(strconv.Itoa(number))
+		return nil
+	}
+}
+
+// stringField converts a nested JSON value into its string encoding.
+func stringField(name string) func(map[string]json.RawMessage) error {
+	return func(fields map[string]json.RawMessage) error {
+		value, ok := fields[name]
+		if !ok || isJSONString(value) || bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			return nil
+		}
+
+		encoded, err := json.Marshal(string(value))
+		if err != nil {
+			return err
+		}
+		fields[name] = encoded
+		return nil
+	}
+}
+
+// timestampField converts Unix timestamps (seconds or milliseconds) into RFC 3339 strings.
+func timestampField(name string) func(map[string]json.RawMessage) error {
+	return func(fields map[string]json.RawMessage) error {
+		value, ok := fields[name]
+		if !ok || isJSONString(value) {
+			return nil
+		}
+
+		var unix float64
+		if err := json.Unmarshal(value, &unix); err != nil {
+			return nil
+		}
+
+		var t time.Time
+		if unix > 1e12 {
+			t = time.UnixMilli(int64(unix))
+		} else {
+			t = time.Unix(int64(unix), 0)
+		}
+
+		encoded, err := json.Marshal(t.UTC())
+		if err != nil {
+			return err
+		}
+		fields[name] = encoded
+		return nil
+	}
+}
+
+// isJSONString reports whether the raw value is a JSON string.
+func isJSONString(value json.RawMessage) bool {
+	value = bytes.TrimSpace(value)
+	return len(value) > 0 && value[0] == '"'
+}
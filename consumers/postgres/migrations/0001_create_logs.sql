@@ -0,0 +1,23 @@
+-- Log table partitioned by month on the log timestamp.
+-- Partitions are created by the writer on demand.
+CREATE TABLE IF NOT EXISTS logs (
+    id                TEXT        NOT NULL,
+    timestamp         TIMESTAMPTZ NOT NULL,
+    error_level       TEXT        NOT NULL,
+    error_code        INTEGER     NOT NULL,
+    client_message_uz TEXT        NOT NULL DEFAULT '',
+    client_message_ru TEXT        NOT NULL DEFAULT '',
+    error_message     TEXT        NOT NULL DEFAULT '',
+    details_uz        TEXT        NOT NULL DEFAULT '',
+    details_ru        TEXT        NOT NULL DEFAULT '',
+    api_endpoint      TEXT        NOT NULL DEFAULT '',
+    method            TEXT        NOT NULL DEFAULT '',
+    function_name     TEXT        NOT NULL DEFAULT '',
+    status_code       INTEGER     NOT NULL DEFAULT 0,
+    request_payload   TEXT        NOT NULL DEFAULT '',
+    event_type        TEXT        NOT NULL DEFAULT '',
+    response_data     TEXT        NOT NULL DEFAULT '',
+    merchant_api_key  TEXT        NOT NULL DEFAULT '',
+    received_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id, timestamp)
+) PARTITION BY RANGE (timestamp);
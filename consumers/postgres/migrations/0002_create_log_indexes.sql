@@ -0,0 +1,5 @@
+-- Indexes for the common investigation filters.
+CREATE INDEX IF NOT EXISTS logs_error_code_timestamp_idx ON logs (error_code, timestamp);
+CREATE INDEX IF NOT EXISTS logs_error_level_timestamp_idx ON logs (error_level, timestamp);
+CREATE INDEX IF NOT EXISTS logs_api_endpoint_timestamp_idx ON logs (api_endpoint, timestamp);
+CREATE INDEX IF NOT EXISTS logs_merchant_api_key_timestamp_idx ON logs (merchant_api_key, timestamp) WHERE merchant_api_key <> '';
@@ -0,0 +1,4 @@
+-- Group ID shared by the logs of one logical operation (see Logger.Group), indexed to
+-- reassemble the operation.
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS group_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS logs_group_id_timestamp_idx ON logs (group_id, timestamp) WHERE group_id <> '';
@@ -0,0 +1,6 @@
+-- User and session of the log (see logger.ContextWithUser), indexed to find every log of a
+-- user for customer support.
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS user_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS session_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS logs_user_id_timestamp_idx ON logs (user_id, timestamp) WHERE user_id <> '';
+CREATE INDEX IF NOT EXISTS logs_session_id_timestamp_idx ON logs (session_id, timestamp) WHERE session_id <> '';
@@ -0,0 +1,10 @@
+-- Client, request and producer fields of the log, indexed for abuse investigations (client IP),
+-- request tracing (request ID) and per-environment dashboards.
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS client_ip TEXT NOT NULL DEFAULT '';
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS request_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS environment TEXT NOT NULL DEFAULT '';
+ALTER TABLE logs ADD COLUMN IF NOT EXISTS duration_ms BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS logs_client_ip_timestamp_idx ON logs (client_ip, timestamp) WHERE client_ip <> '';
+CREATE INDEX IF NOT EXISTS logs_request_id_idx ON logs (request_id) WHERE request_id <> '';
+CREATE INDEX IF NOT EXISTS logs_environment_timestamp_idx ON logs (environment, timestamp);
@@ -0,0 +1,168 @@
+package consumers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// IsProto reports whether the body looks like a protobuf encoded log rather than JSON or MessagePack.
+// Protobuf logs start with their timestamp (field 1, length-delimited: 0x0a), which JSON bodies
+// only share when they start with a newline before the object.
+func IsProto(body []byte) bool {
+	if len(body) == 0 || body[0] != 0x0a {
+		return false
+	}
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	return len(trimmed) == 0 || trimmed[0] != '{'
+}
+
+// DecodeProto decodes a protobuf encoded log message (mybazar.logger.v1.Log) into a Delivery.
+// The delivery body is re-encoded as JSON, so handlers storing raw bodies work with all encodings;
+// like with JSON messages, compressed payload fields are only decompressed in the record.
+func DecodeProto(body []byte) (Delivery, error) {
+	var log logpb.Log
+	if err := proto.Unmarshal(body, &log); err != nil {
+		return Delivery{}, fmt.Errorf("failed to decode protobuf log: %w", err)
+	}
+
+	record := RecordFromProto(&log)
+	converted, err := json.Marshal(record)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("failed to encode log: %w", err)
+	}
+	if err := Decompress(&record); err != nil {
+		return Delivery{}, err
+	}
+
+	d, _ := DecodeRaw(body)
+	d.Record = record
+	d.Body = converted
+	return d, nil
+}
+
+// RecordFromProto converts a protobuf log into a Record.
+func RecordFromProto(log *logpb.Log) Record {
+	return Record{
+		Timestamp:         log.GetTimestamp().AsTime(),
+		ErrorLevel:        levelName(log.GetLevel()),
+		Errorcode:         int(log.GetErrorCode()),
+		ClientMessageUz:   log.GetClientMessageUz(),
+		ClientMessageRu:   log.GetClientMessageRu(),
+		ErrorMessage:      log.GetErrorMessage(),
+		DetailsUz:         log.GetDetailsUz(),
+		DetailsRu:         log.GetDetailsRu(),
+		ApiEndpoint:       log.GetApiEndpoint(),
+		Method:            log.GetMethod(),
+		FunctionName:      log.GetFunctionName(),
+		StatusCode:        int(log.GetStatusCode()),
+		RequestPayload:    log.GetRequestPayload(),
+		EventType:         log.GetEventType(),
+		ResponseData:      log.GetResponseData(),
+		MerchantApiKey:    log.GetMerchantApiKey(),
+		DurationMs:        log.GetDurationMs(),
+		RequestPayloadRef: blobRefFromProto(log.GetRequestPayloadRef()),
+		ResponseDataRef:   blobRefFromProto(log.GetResponseDataRef()),
+
+		RequestPayloadEncoding: log.GetRequestPayloadEncoding(),
+		ResponseDataEncoding:   log.GetResponseDataEncoding(),
+		ValidationFailed:       log.GetValidationFailed(),
+		ValidationError:        log.GetValidationError(),
+		ProducerID:             log.GetProducerId(),
+		Sequence:               log.GetSequence(),
+		SchemaVersion:          int(log.GetSchemaVersion()),
+		Host:                   log.GetHost(),
+		Pod:                    log.GetPod(),
+		Service:                log.GetService(),
+		InstanceID:             log.GetInstanceId(),
+		Environment:            log.GetEnvironment(),
+		AppVersion:             log.GetAppVersion(),
+		GitCommit:              log.GetGitCommit(),
+		UserID:                 log.GetUserId(),
+		SessionID:              log.GetSessionId(),
+		ClientIP:               log.GetClientIp(),
+		UserAgent:              log.GetUserAgent(),
+		RequestID:              log.GetRequestId(),
+		Errors:                 errorDetailsFromProto(log.GetErrors()),
+		Extra:                  extraFromProto(log.GetExtra()),
+		ExpiresAt:              timeFromProto(log.GetExpiresAt()),
+		MessageID:              log.GetMessageId(),
+		Attachments:            attachmentsFromProto(log.GetAttachments()),
+		GroupID:                log.GetGroupId(),
+	}
+}
+
+// timeFromProto converts an optional protobuf timestamp; nil stays nil.
+func timeFromProto(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.AsTime()
+	return &v
+}
+
+// attachmentsFromProto converts protobuf attachments; an empty list stays nil.
+func attachmentsFromProto(attachments []*logpb.Attachment) []Attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	result := make([]Attachment, len(attachments))
+	for i, a := range attachments {
+		result[i] = Attachment{Name: a.GetName(), ContentType: a.GetContentType(), Data: a.GetData(), Ref: blobRefFromProto(a.GetRef())}
+	}
+	return result
+}
+
+// blobRefFromProto converts a protobuf reference into a BlobRef; nil stays nil.
+func blobRefFromProto(ref *logpb.BlobRef) *BlobRef {
+	if ref == nil {
+		return nil
+	}
+	return &BlobRef{Bucket: ref.GetBucket(), Key: ref.GetKey(), Size: ref.GetSize(), SHA256: ref.GetSha256()}
+}
+
+// levelName returns the `error_level` name of a protobuf level.
+func levelName(level logpb.Level) string {
+	switch level {
+	case logpb.Level_LEVEL_DEBUG:
+		return "debug"
+	case logpb.Level_LEVEL_WARNING:
+		return "warning"
+	case logpb.Level_LEVEL_ERROR:
+		return "error"
+	case logpb.Level_LEVEL_CRITICAL:
+		return "critical"
+	default:
+		return "info"
+	}
+}
+
+// extraFromProto decodes the extra metadata of a protobuf log, carried as a JSON object.
+func extraFromProto(extra string) map[string]any {
+	if extra == "" {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(extra), &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// errorDetailsFromProto converts the error details of a protobuf log.
+func errorDetailsFromProto(messages []*logpb.ErrorDetail) []logger.ErrorDetail {
+	if len(messages) == 0 {
+		return nil
+	}
+	details := make([]logger.ErrorDetail, len(messages))
+	for i, m := range messages {
+		details[i] = logger.ErrorDetail{Code: logger.Errorcode(m.GetCode()), Message: m.GetMessage()}
+	}
+	return details
+}
@@ -0,0 +1,14 @@
+package consumers
+
+import "github.com/kupalovmuhammadjon/mybazar-logger/logger"
+
+// Record is a log message as published by the logger to the log queue.
+// Decode decompresses the payload fields of producers using WithPayloadCompression and
+// clears their encodings. Producers predating a field leave it empty, e.g. SchemaVersion is zero.
+type Record = logger.LogRecord
+
+// BlobRef points to a payload the producer offloaded to object storage.
+type BlobRef = logger.BlobRef
+
+// Attachment is a file the producer attached to a log; offloaded content is referenced by Ref.
+type Attachment = logger.Attachment
@@ -0,0 +1,142 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+)
+
+// Policy maps a log level (as published in `error_level`) to how long its logs are kept.
+// Levels missing from the policy are kept forever.
+type Policy map[string]time.Duration
+
+// DefaultPolicy keeps debug logs for a day, info logs for 7 days, warnings for 30 days, errors for 90 days
+// and critical logs for a year.
+func DefaultPolicy() Policy {
+	return Policy{
+		"debug":    24 * time.Hour,
+		"info":     7 * 24 * time.Hour,
+		"warning":  30 * 24 * time.Hour,
+		"error":    90 * 24 * time.Hour,
+		"critical": 365 * 24 * time.Hour,
+	}
+}
+
+// Store is a log store that can delete old logs.
+type Store interface {
+	// Purge deletes the logs of the level older than the cutoff and returns how many were deleted,
+	// or -1 if the store does not report it.
+	Purge(ctx context.Context, level string, before time.Time) (int64, error)
+}
+
+// Exporter is implemented by stores that can read old logs back, which is required for archiving.
+type Exporter interface {
+	// Export hands the logs of the level older than the cutoff to fn in batches of at most batchSize logs.
+	Export(ctx context.Context, level string, before time.Time, batchSize int, fn consumers.BatchHandler) error
+}
+
+// Config holds the retention job settings.
+type Config struct {
+	Policy   Policy        // Retention per level. Defaults to DefaultPolicy.
+	Interval time.Duration // Interval between two runs of the scheduled job. Defaults to 1 hour.
+
+	// Archive receives the expired logs before they are deleted, e.g. s3.Archiver.Write.
+	// Archiving requires a store implementing Exporter; if archiving fails the logs are not deleted.
+	Archive   consumers.BatchHandler
+	BatchSize int // Number of logs handed to Archive at once. Defaults to 1000.
+
+	// OnPurge is called after the expired logs of a level were deleted; optional.
+	OnPurge func(level string, before time.Time, deleted int64)
+
+	// OnError is called with the errors of scheduled runs; optional.
+	OnError func(err error)
+}
+
+// Job deletes (and optionally archives) expired logs according to the retention policy.
+type Job struct {
+	store  Store  // Store the logs are deleted from.
+	config Config // Job settings with defaults applied.
+}
+
+// NewJob initializes and returns a new Job instance.
+// It returns an error if archiving is configured for a store that cannot export logs.
+//
+// Usage:
+//
+//	job, _ := retention.NewJob(postgres.NewWriter(db), retention.Config{Archive: s3.NewArchiver(client, s3Config).Write})
+//	go job.Run(ctx)
+func NewJob(store Store, config Config) (*Job, error) {
+	if config.Policy == nil {
+		config.Policy = DefaultPolicy()
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+	if _, ok := store.(Exporter); config.Archive != nil && !ok {
+		return nil, errors.New("archiving requires a store that can export logs")
+	}
+
+	return &Job{store: store, config: config}, nil
+}
+
+// Run applies the policy every interval until the context is cancelled.
+func (j *Job) Run(ctx context.Context) error {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.RunOnce(ctx); err != nil && j.config.OnError != nil {
+			j.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce applies the policy once, level by level.
+func (j *Job) RunOnce(ctx context.Context) error {
+	levels := make([]string, 0, len(j.config.Policy))
+	for level := range j.config.Policy {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+
+	var errs []error
+	for _, level := range levels {
+		before := time.Now().Add(-j.config.Policy[level])
+		if err := j.apply(ctx, level, before); err != nil {
+			errs = append(errs, fmt.Errorf("retention of %s logs: %w", level, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// apply archives and deletes the logs of one level.
+func (j *Job) apply(ctx context.Context, level string, before time.Time) error {
+	if j.config.Archive != nil {
+		exporter := j.store.(Exporter)
+		if err := exporter.Export(ctx, level, before, j.config.BatchSize, j.config.Archive); err != nil {
+			return fmt.Errorf("archive failed: %w", err)
+		}
+	}
+
+	deleted, err := j.store.Purge(ctx, level, before)
+	if err != nil {
+		return fmt.Errorf("purge failed: %w", err)
+	}
+	if j.config.OnPurge != nil {
+		j.config.OnPurge(level, before, deleted)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// AnomalyConfig holds the anomaly detection settings.
+type AnomalyConfig struct {
+	// Logger publishes the alerts as Critical logs carrying the spiking error code,
+	// so they reach the usual sinks and alert rules.
+	Logger logger.Logger
+
+	Alpha     float64       // EWMA smoothing factor between 0 and 1. Defaults to 0.3.
+	Threshold float64       // Z-score above which a window count is anomalous. Defaults to 3.
+	MinCount  int           // Minimal count of an anomalous window, ignoring spikes on tiny volumes. Defaults to 10.
+	WarmUp    int           // Number of windows a series is observed before it can alert. Defaults to 10.
+	Cooldown  time.Duration // Minimal time between two alerts of the same series. Defaults to 15 minutes.
+
+	// Levels are the levels watched for spikes. Defaults to error and critical.
+	Levels []logger.Level
+}
+
+// Detector raises alerts when the count of an error code spikes abnormally compared to
+// its exponentially weighted moving average, before static thresholds of alert rules fire.
+type Detector struct {
+	config     AnomalyConfig   // Detection settings with defaults applied.
+	levels     map[string]bool // Watched level names.
+	mu         sync.Mutex      // Protects series and lastWindow.
+	series     map[Key]*series // Moving statistics per group.
+	lastWindow time.Time       // Start of the latest observed window.
+}
+
+// series holds the moving statistics of one group.
+type series struct {
+	mean      float64   // EWMA of the window counts.
+	variance  float64   // Exponentially weighted variance of the window counts.
+	observed  int       // Number of observed windows.
+	lastAlert time.Time // Time of the latest alert.
+}
+
+// Anomaly describes a detected spike; it is the payload of the alert.
+type Anomaly struct {
+	Key
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	Count       int       `json:"count"`
+	Mean        float64   `json:"mean"`
+	StdDev      float64   `json:"stddev"`
+	ZScore      float64   `json:"z_score"`
+}
+
+// NewDetector initializes and returns a new Detector instance.
+// It returns an error if no logger is configured.
+//
+// Usage:
+//
+//	detector, _ := rollup.NewDetector(rollup.AnomalyConfig{Logger: log})
+//	err := rollup.Run(ctx, rollup.Config{Emit: rollup.PublishTo(transport, "logs.rollup"), Detector: detector}, consumerConfig)
+func NewDetector(config AnomalyConfig) (*Detector, error) {
+	if config.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	if config.Alpha <= 0 || config.Alpha > 1 {
+		config.Alpha = 0.3
+	}
+	if config.Threshold <= 0 {
+		config.Threshold = 3
+	}
+	if config.MinCount <= 0 {
+		config.MinCount = 10
+	}
+	if config.WarmUp <= 0 {
+		config.WarmUp = 10
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 15 * time.Minute
+	}
+	if len(config.Levels) == 0 {
+		config.Levels = []logger.Level{logger.LevelError, logger.LevelCritical}
+	}
+
+	levels := make(map[string]bool, len(config.Levels))
+	for _, level := range config.Levels {
+		levels[level.String()] = true
+	}
+
+	return &Detector{
+		config: config,
+		levels: levels,
+		series: make(map[Key]*series),
+	}, nil
+}
+
+// Observe updates the statistics with the rollups of one window and publishes an alert for every anomaly.
+// Observe has the EmitFunc signature. Windows older than the latest observed one are ignored.
+func (d *Detector) Observe(ctx context.Context, rollups []Rollup) error {
+	if len(rollups) == 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	anomalies := d.observe(rollups)
+	d.mu.Unlock()
+
+	var errs []error
+	for _, a := range anomalies {
+		if err := d.alert(a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// observe updates the statistics and returns the anomalies of the window.
+func (d *Detector) observe(rollups []Rollup) []Anomaly {
+	start, end := rollups[0].WindowStart, rollups[0].WindowEnd
+	if !start.After(d.lastWindow) {
+		return nil
+	}
+
+	// Windows without any log are never emitted; they count as zero for every known group.
+	if !d.lastWindow.IsZero() {
+		gaps := int(start.Sub(d.lastWindow)/end.Sub(start)) - 1
+		for i := 0; i < min(gaps, 100); i++ {
+			for _, s := range d.series {
+				s.update(0, d.config.Alpha)
+			}
+		}
+	}
+	d.lastWindow = start
+
+	counts := make(map[Key]int, len(rollups))
+	for _, r := range rollups {
+		if d.levels[r.Level] {
+			counts[r.Key] = r.Count
+		}
+	}
+	for key := range d.series {
+		if _, ok := counts[key]; !ok {
+			counts[key] = 0
+		}
+	}
+
+	var anomalies []Anomaly
+	for key, count := range counts {
+		s, ok := d.series[key]
+		if !ok {
+			s = &series{}
+			d.series[key] = s
+		}
+
+		stddev := math.Sqrt(s.variance)
+		// A floor of one log keeps perfectly stable series from alerting on a single extra log.
+		z := (float64(count) - s.mean) / math.Max(stddev, 1)
+
+		if s.observed >= d.config.WarmUp && count >= d.config.MinCount && z >= d.config.Threshold &&
+			end.Sub(s.lastAlert) >= d.config.Cooldown {
+			s.lastAlert = end
+			anomalies = append(anomalies, Anomaly{
+				Key:         key,
+				WindowStart: start,
+				WindowEnd:   end,
+				Count:       count,
+				Mean:        s.mean,
+				StdDev:      stddev,
+				ZScore:      z,
+			})
+		}
+
+		s.update(float64(count), d.config.Alpha)
+
+		// Forget groups that have been quiet for long, keeping memory bounded.
+		if s.observed > d.config.WarmUp && s.mean < 0.01 {
+			delete(d.series, key)
+		}
+	}
+	return anomalies
+}
+
+// update adds a window count to the moving statistics.
+func (s *series) update(count, alpha float64) {
+	if s.observed == 0 {
+		s.mean = count
+	} else {
+		diff := count - s.mean
+		increment := alpha * diff
+		s.mean += increment
+		s.variance = (1 - alpha) * (s.variance + diff*increment)
+	}
+	s.observed++
+}
+
+// alert publishes the anomaly as a Critical log.
+func (d *Detector) alert(a Anomaly) error {
+	return d.config.Logger.Critical(logger.LogRequest{
+		Errorcode:       logger.Errorcode(a.Errorcode),
+		ClientMessageUz: fmt.Sprintf("%d xatolik kodi keskin ko'paydi", a.Errorcode),
+		ClientMessageRu: fmt.Sprintf("Резкий рост ошибок с кодом %d", a.Errorcode),
+		ErrorMessage: fmt.Sprintf("anomaly: %s logged %d %s logs with code %d in %s (mean %.1f, stddev %.1f, z-score %.1f)",
+			a.Service, a.Count, a.Level, a.Errorcode, a.WindowEnd.Sub(a.WindowStart), a.Mean, a.StdDev, a.ZScore),
+		RequestPayload: a,
+		EventType:      logger.EventAnomalyDetected,
+	})
+}
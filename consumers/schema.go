@@ -0,0 +1,145 @@
+package consumers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logpb"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrUnsupportedSchemaVersion is returned for logs of a schema newer than the consumer supports.
+var ErrUnsupportedSchemaVersion = logger.ErrUnsupportedSchemaVersion
+
+// UnknownFieldsError reports the fields of a log the consumer does not know.
+type UnknownFieldsError struct {
+	Fields []string // Names of the unknown JSON fields, or numbers of the unknown protobuf fields.
+}
+
+// Error lists the unknown fields.
+func (e *UnknownFieldsError) Error() string {
+	return "unknown log fields: " + strings.Join(e.Fields, ", ")
+}
+
+// StrictDecoder decodes log messages like Decode, additionally checking their schema version
+// and fields, so producers and consumers can be deployed independently: logs of a newer
+// schema are rejected instead of being stored half-understood, and fields added by newer
+// producers of the same schema are noticed.
+//
+// Usage:
+//
+//	decoder := consumers.StrictDecoder{
+//		OnUnknownFields: func(d consumers.Delivery, fields []string) {
+//			slog.Warn("log with unknown fields", "fields", fields, "producer", d.Record.FunctionName)
+//		},
+//	}
+//	consumer := consumers.New(consumers.Config{URL: url, Queue: "logs", Decode: decoder.Decode})
+type StrictDecoder struct {
+	// MaxSchemaVersion is the newest schema version accepted. Defaults to logger.SchemaVersion.
+	MaxSchemaVersion int
+
+	// OnUnknownFields is called for logs carrying unknown fields, which are decoded anyway.
+	// When nil, such logs fail to decode with an *UnknownFieldsError.
+	OnUnknownFields func(d Delivery, fields []string)
+}
+
+// Decode decodes and checks a log message.
+func (s StrictDecoder) Decode(body []byte) (Delivery, error) {
+	d, err := Decode(body)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	maxVersion := s.MaxSchemaVersion
+	if maxVersion == 0 {
+		maxVersion = logger.SchemaVersion
+	}
+	if d.Record.SchemaVersion > maxVersion {
+		return Delivery{}, fmt.Errorf("%w: %d (supported up to %d)", ErrUnsupportedSchemaVersion, d.Record.SchemaVersion, maxVersion)
+	}
+
+	var fields []string
+	if IsProto(body) {
+		fields, err = unknownProtoFields(body)
+	} else {
+		// MessagePack bodies are converted to JSON by Decode.
+		fields, err = unknownJSONFields(d.Body)
+	}
+	if err != nil {
+		return Delivery{}, err
+	}
+	if len(fields) == 0 {
+		return d, nil
+	}
+	if s.OnUnknownFields == nil {
+		return Delivery{}, &UnknownFieldsError{Fields: fields}
+	}
+	s.OnUnknownFields(d, fields)
+	return d, nil
+}
+
+// recordFields are the JSON field names of Record.
+var recordFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(Record{})
+	for i := range t.NumField() {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields[name] = true
+	}
+	return fields
+}()
+
+// unknownJSONFields returns the sorted names of the top-level fields of a JSON log missing from Record.
+// Legacy names renamed by DefaultNormalizer count as known.
+func unknownJSONFields(body []byte) ([]string, error) {
+	normalized, err := DefaultNormalizer.Normalize(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize log: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(normalized, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode log: %w", err)
+	}
+
+	var unknown []string
+	for name := range fields {
+		if !recordFields[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown, nil
+}
+
+// unknownProtoFields returns the numbers of the fields of a protobuf log missing from mybazar.logger.v1.Log.
+func unknownProtoFields(body []byte) ([]string, error) {
+	known := (&logpb.Log{}).ProtoReflect().Descriptor().Fields()
+
+	var unknown []string
+	for len(body) > 0 {
+		number, typ, n := protowire.ConsumeTag(body)
+		if n < 0 {
+			return nil, fmt.Errorf("failed to decode protobuf log: %w", protowire.ParseError(n))
+		}
+		body = body[n:]
+		if known.ByNumber(number) == nil {
+			unknown = append(unknown, strconv.Itoa(int(number)))
+		}
+		n = protowire.ConsumeFieldValue(number, typ, body)
+		if n < 0 {
+			return nil, fmt.Errorf("failed to decode protobuf log: %w", protowire.ParseError(n))
+		}
+		body = body[n:]
+	}
+	slices.Sort(unknown)
+	return slices.Compact(unknown), nil
+}
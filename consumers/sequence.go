@@ -0,0 +1,55 @@
+package consumers
+
+import "sync"
+
+// SequenceTracker detects gaps (dropped logs) and reorderings in the stream from the
+// sequence numbers the producers stamp on their logs. It is safe for concurrent use.
+// Every producer instance is remembered, so long-running consumers should Reset it
+// periodically (e.g. daily) to forget restarted producers.
+//
+// Usage:
+//
+//	tracker := consumers.NewSequenceTracker()
+//	missing, reordered := tracker.Observe(record)
+//	droppedLogs.Add(float64(missing))
+type SequenceTracker struct {
+	mu   sync.Mutex        // Protects last.
+	last map[string]uint64 // Highest sequence number seen per producer ID.
+}
+
+// NewSequenceTracker returns an empty tracker.
+func NewSequenceTracker() *SequenceTracker {
+	return &SequenceTracker{last: make(map[string]uint64)}
+}
+
+// Observe records the sequence number of the record. It returns the number of logs of the
+// producer missing right before it, and whether the record arrived after a later one (such
+// a record was counted as missing before) or is a redelivered duplicate. Records of producers without sequence numbers are ignored.
+func (t *SequenceTracker) Observe(record Record) (missing uint64, reordered bool) {
+	if record.ProducerID == "" || record.Sequence == 0 {
+		return 0, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last, ok := t.last[record.ProducerID]
+	switch {
+	case !ok:
+		// The first record of a producer: earlier ones may predate the consumer.
+		t.last[record.ProducerID] = record.Sequence
+		return 0, false
+	case record.Sequence <= last:
+		return 0, true
+	default:
+		t.last[record.ProducerID] = record.Sequence
+		return record.Sequence - last - 1, false
+	}
+}
+
+// Reset forgets all producers.
+func (t *SequenceTracker) Reset() {
+	t.mu.Lock()
+	t.last = make(map[string]uint64)
+	t.mu.Unlock()
+}
@@ -0,0 +1,248 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// Config holds the ingestion server settings.
+type Config struct {
+	Transport logger.Transport // Transport the accepted logs are published through.
+	Queue     string           // Queue the logs are published to.
+
+	// Authenticate returns the producer name of an API key, which is published as the log function name.
+	// A false result rejects the request with 401.
+	Authenticate func(apiKey string) (producer string, ok bool)
+
+	MaxBodyBytes int64 // Maximum size of a request body. Defaults to 1 MiB.
+	MaxBatchSize int   // Maximum number of logs in a single request. Defaults to 500.
+}
+
+// Entry is a log as accepted by the ingestion endpoint: a LogRequest with its level.
+// The level defaults to info.
+type Entry struct {
+	Level logger.Level `json:"level"`
+	logger.LogRequest
+}
+
+// Response is the body returned by the ingestion endpoint.
+type Response struct {
+	Accepted int          `json:"accepted"`         // Number of published logs.
+	Errors   []EntryError `json:"errors,omitempty"` // Logs that were rejected.
+	Error    string       `json:"error,omitempty"`  // Reason the whole request was rejected.
+}
+
+// EntryError describes a rejected log of a batch.
+type EntryError struct {
+	Index     int    `json:"index"`               // Position of the log in the request.
+	Error     string `json:"error"`               // Reason the log was rejected.
+	Retryable bool   `json:"retryable,omitempty"` // Set for logs left unpublished by a publish failure, which may be sent again.
+}
+
+// Server accepts logs over HTTP and publishes them into the log queue, for producers that
+// cannot speak AMQP (front-end, mobile crash reporters, legacy PHP services).
+type Server struct {
+	config Config // Server settings with defaults applied.
+
+	mu      sync.Mutex               // Protects loggers.
+	loggers map[string]logger.Logger // Loggers per producer.
+}
+
+// New initializes and returns a new Server instance.
+// It returns an error if the configuration is incomplete.
+//
+// Usage:
+//
+//	srv, _ := ingest.New(ingest.Config{
+//		Transport:    logger.NewRabbitMQTransport(rmq),
+//		Queue:        "logs",
+//		Authenticate: func(key string) (string, bool) { name, ok := apiKeys[key]; return name, ok },
+//	})
+//	http.ListenAndServe(":8080", srv.Handler())
+func New(config Config) (*Server, error) {
+	if config.Transport == nil {
+		return nil, errors.New("transport is required")
+	}
+	if config.Queue == "" {
+		return nil, errors.New("queue is required")
+	}
+	if config.Authenticate == nil {
+		return nil, errors.New("authenticate function is required")
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = 1 << 20
+	}
+	if config.MaxBatchSize <= 0 {
+		config.MaxBatchSize = 500
+	}
+
+	return &Server{
+		config:  config,
+		loggers: make(map[string]logger.Logger),
+	}, nil
+}
+
+// Handler returns the HTTP handler serving `POST /v1/logs`.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/logs", s)
+	return mux
+}
+
+// ServeHTTP accepts a single log object or an array of logs.
+// The API key is read from the X-API-Key header or an `Authorization: Bearer` header.
+//
+// It responds with 202 if every log was published, 207 if only part of a batch was,
+// 422 if every log was rejected and 503 if publishing failed before any log was published.
+// When publishing fails partway through a batch, the published logs are counted in Accepted
+// and the unpublished ones are reported with a 207 as retryable errors, so clients resend only
+// those instead of duplicating the published ones.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeResponse(w, http.StatusMethodNotAllowed, Response{Error: "method not allowed"})
+		return
+	}
+
+	producer, ok := s.config.Authenticate(apiKey(r))
+	if !ok {
+		writeResponse(w, http.StatusUnauthorized, Response{Error: "invalid API key"})
+		return
+	}
+
+	entries, status, err := s.decode(w, r)
+	if err != nil {
+		writeResponse(w, status, Response{Error: err.Error()})
+		return
+	}
+
+	l, err := s.logger(producer)
+	if err != nil {
+		writeResponse(w, http.StatusServiceUnavailable, Response{Error: err.Error()})
+		return
+	}
+
+	var resp Response
+	for i, entry := range entries {
+		if err := publish(l, entry); err != nil {
+			if !errors.Is(err, logger.ErrInvalidLog) {
+				resp.Error = fmt.Sprintf("failed to publish log: %s", err)
+				if resp.Accepted == 0 {
+					writeResponse(w, http.StatusServiceUnavailable, resp)
+					return
+				}
+				// The logs before are published: report the unpublished tail so that only it is retried.
+				for j := i; j < len(entries); j++ {
+					resp.Errors = append(resp.Errors, EntryError{Index: j, Error: resp.Error, Retryable: true})
+				}
+				writeResponse(w, http.StatusMultiStatus, resp)
+				return
+			}
+			resp.Errors = append(resp.Errors, EntryError{Index: i, Error: err.Error()})
+			continue
+		}
+		resp.Accepted++
+	}
+
+	switch {
+	case resp.Accepted == 0:
+		writeResponse(w, http.StatusUnprocessableEntity, resp)
+	case len(resp.Errors) > 0:
+		writeResponse(w, http.StatusMultiStatus, resp)
+	default:
+		writeResponse(w, http.StatusAccepted, resp)
+	}
+}
+
+// decode reads the request body as a single entry or an array of entries.
+func (s *Server) decode(w http.ResponseWriter, r *http.Request) ([]Entry, int, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("body exceeds %d bytes", tooLarge.Limit)
+		}
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	var entries []Entry
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &entries)
+	} else {
+		var entry Entry
+		err = json.Unmarshal(trimmed, &entry)
+		entries = []Entry{entry}
+	}
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, http.StatusBadRequest, errors.New("no logs in request")
+	}
+	if len(entries) > s.config.MaxBatchSize {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("batch exceeds %d logs", s.config.MaxBatchSize)
+	}
+	return entries, 0, nil
+}
+
+// logger returns the logger publishing on behalf of the producer, creating it on first use.
+func (s *Server) logger(producer string) (logger.Logger, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.loggers[producer]; ok {
+		return l, nil
+	}
+
+	l, err := logger.NewLoggerWithTransport(s.config.Transport, s.config.Queue, producer, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.loggers[producer] = l
+	return l, nil
+}
+
+// publish logs the entry with its level.
+func publish(l logger.Logger, entry Entry) error {
+	switch entry.Level {
+	case logger.LevelDebug:
+		return l.Debug(entry.LogRequest)
+	case logger.LevelInfo:
+		return l.Info(entry.LogRequest)
+	case logger.LevelWarn:
+		return l.Warn(entry.LogRequest)
+	case logger.LevelError:
+		return l.Error(entry.LogRequest)
+	case logger.LevelCritical:
+		return l.Critical(entry.LogRequest)
+	default:
+		return fmt.Errorf("%w: unknown level %s", logger.ErrInvalidLog, entry.Level)
+	}
+}
+
+// apiKey returns the API key of the request.
+func apiKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+// writeResponse encodes the response as JSON with the given status.
+func writeResponse(w http.ResponseWriter, status int, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,130 @@
+package kafkatransport
+
+import (
+	"context"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/segmentio/kafka-go"
+)
+
+// Config holds the settings of the Kafka transport.
+type Config struct {
+	Brokers []string // Kafka broker addresses (host:port).
+
+	// TopicPerLevel publishes log messages to "<topic>.<level>" topics (e.g. "logs.error")
+	// instead of a single topic. In single-topic mode the level is only carried in the "level" header.
+	TopicPerLevel bool
+
+	BatchTimeout time.Duration // Maximum time a message waits for a batch to fill up. Defaults to 10ms.
+	WriteTimeout time.Duration // Timeout of a single publish. Defaults to 10s.
+}
+
+// transport is the logger.Transport implementation backed by a Kafka producer.
+type transport struct {
+	writer        *kafka.Writer // Kafka producer shared by all topics.
+	topicPerLevel bool          // Whether log messages are split into per-level topics.
+	writeTimeout  time.Duration // Timeout of a single publish.
+}
+
+// New initializes and returns a Kafka transport for the logger.
+//
+// Usage:
+//
+//	t := kafkatransport.New(kafkatransport.Config{Brokers: []string{"localhost:9092"}})
+//	log, err := logger.NewLoggerWithTransport(t, "logs", "CreateOrder", "/api/v1/orders", nil, nil)
+func New(cfg Config) logger.Transport {
+	if cfg.BatchTimeout == 0 {
+		cfg.BatchTimeout = 10 * time.Millisecond
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = 10 * time.Second
+	}
+
+	return &transport{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(cfg.Brokers...),
+			Balancer:               &kafka.LeastBytes{},
+			BatchTimeout:           cfg.BatchTimeout,
+			RequiredAcks:           kafka.RequireOne,
+			AllowAutoTopicCreation: true,
+		},
+		topicPerLevel: cfg.TopicPerLevel,
+		writeTimeout:  cfg.WriteTimeout,
+	}
+}
+
+// Declare is a no-op: topics are created by the broker on first publish.
+func (t *transport) Declare(destination string) error {
+	return nil
+}
+
+// Publish writes the message to its topic, copying the level and message headers into Kafka headers.
+func (t *transport) Publish(msg logger.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.writeTimeout)
+	defer cancel()
+
+	return t.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   t.topic(msg),
+		Value:   msg.Body,
+		Headers: headers(msg),
+	})
+}
+
+// PublishBatch writes the messages in a single request per partition.
+func (t *transport) PublishBatch(msgs []logger.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.writeTimeout)
+	defer cancel()
+
+	batch := make([]kafka.Message, len(msgs))
+	for i, msg := range msgs {
+		batch[i] = kafka.Message{
+			Topic:   t.topic(msg),
+			Value:   msg.Body,
+			Headers: headers(msg),
+		}
+	}
+	return t.writer.WriteMessages(ctx, batch...)
+}
+
+// Ping fetches the cluster metadata from the brokers.
+func (t *transport) Ping(ctx context.Context) error {
+	client := &kafka.Client{Addr: t.writer.Addr, Timeout: t.writeTimeout}
+	_, err := client.Metadata(ctx, &kafka.MetadataRequest{})
+	return err
+}
+
+// Close flushes pending messages and closes the producer.
+func (t *transport) Close() error {
+	return t.writer.Close()
+}
+
+// topic returns the Kafka topic for the message.
+func (t *transport) topic(msg logger.Message) string {
+	if t.topicPerLevel && msg.Level != "" {
+		return msg.Destination + "." + msg.Level
+	}
+	return msg.Destination
+}
+
+// headers converts the message level, content type, ID, expiration and headers into Kafka headers.
+// Kafka has no per-message expiry, so the expiration is passed on as the `expires-at` header.
+func headers(msg logger.Message) []kafka.Header {
+	result := make([]kafka.Header, 0, len(msg.Headers)+4)
+	if msg.Level != "" {
+		result = append(result, kafka.Header{Key: "level", Value: []byte(msg.Level)})
+	}
+	if msg.ContentType != "" {
+		result = append(result, kafka.Header{Key: "content-type", Value: []byte(msg.ContentType)})
+	}
+	if msg.ID != "" {
+		result = append(result, kafka.Header{Key: "message-id", Value: []byte(msg.ID)})
+	}
+	if !msg.ExpiresAt.IsZero() {
+		result = append(result, kafka.Header{Key: "expires-at", Value: msg.ExpiresAt.AppendFormat(nil, time.RFC3339Nano)})
+	}
+	for key, value := range msg.Headers {
+		result = append(result, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	return result
+}
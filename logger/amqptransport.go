@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ErrTransportClosed is returned by a transport used after Close.
+var ErrTransportClosed = errors.New("transport is closed")
+
+// amqpTransport is the Transport implementation publishing through an amqp091 channel, with the
+// message properties the rabbitmq client cannot set.
+type amqpTransport struct {
+	url    string           // Broker URL redialed after the connection dropped; empty when the caller owns the connection.
+	mu     sync.Mutex       // Protects conn, ch and closed.
+	conn   *amqp.Connection // Connection the channel is opened on.
+	ch     *amqp.Channel    // Publishing channel, reopened after the broker closed it.
+	closed bool             // Set by Close.
+}
+
+// NewAMQPTransport returns a Transport publishing through a channel of the amqp091 connection.
+// Unlike NewRabbitMQTransport, it sets the AMQP properties of every message: the content type
+// of the encoding, the headers, the expiration of logs with a TTL and the message ID, so
+// consumers can rely on them. Queues are declared as durable and auto-deleted, like with
+// NewRabbitMQTransport. A channel closed by the broker is reopened on the next publish, but the
+// connection belongs to the caller, who must reconnect after it dropped; Close only closes the
+// channel. See DialAMQPTransport for a transport owning its connection.
+//
+// Usage:
+//
+//	conn, err := amqp.Dial(url)
+//	transport, err := logger.NewAMQPTransport(conn)
+//	log, err := logger.NewLoggerWithTransport(transport, "logs", "SyncStock", "/internal/stock", nil, nil,
+//		logger.WithEncoding(logger.EncodingProtobuf),
+//	)
+func NewAMQPTransport(conn *amqp.Connection) (Transport, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	return &amqpTransport{conn: conn, ch: ch}, nil
+}
+
+// DialAMQPTransport connects to the broker and returns a Transport like NewAMQPTransport that
+// owns its connection: it redials on the next publish after the connection dropped, and Close
+// closes the connection.
+func DialAMQPTransport(url string) (Transport, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to rabbitmq: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	return &amqpTransport{url: url, conn: conn, ch: ch}, nil
+}
+
+// channel returns the publishing channel, reopening it (and redialing an owned connection)
+// when it was closed.
+func (t *amqpTransport) channel() (*amqp.Channel, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, ErrTransportClosed
+	}
+	if !t.ch.IsClosed() {
+		return t.ch, nil
+	}
+	if t.conn.IsClosed() && t.url != "" {
+		conn, err := amqp.Dial(t.url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconnect to rabbitmq: %w", err)
+		}
+		t.conn = conn
+	}
+	ch, err := t.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen channel: %w", err)
+	}
+	t.ch = ch
+	return ch, nil
+}
+
+// Declare declares the queue on the RabbitMQ server.
+func (t *amqpTransport) Declare(destination string) error {
+	ch, err := t.channel()
+	if err != nil {
+		return err
+	}
+	_, err = ch.QueueDeclare(destination, true, true, false, false, nil)
+	return err
+}
+
+// Publish publishes the message to the destination queue with its AMQP properties.
+func (t *amqpTransport) Publish(msg Message) error {
+	ch, err := t.channel()
+	if err != nil {
+		return err
+	}
+	return ch.PublishWithContext(context.Background(), "", msg.Destination, false, false, publishing(msg))
+}
+
+// publishing returns the AMQP message of the message.
+func publishing(msg Message) amqp.Publishing {
+	p := amqp.Publishing{
+		ContentType: msg.ContentType,
+		MessageId:   msg.ID,
+		Body:        msg.Body,
+	}
+	if len(msg.Headers) > 0 {
+		p.Headers = make(amqp.Table, len(msg.Headers))
+		for key, value := range msg.Headers {
+			p.Headers[key] = value
+		}
+	}
+	if !msg.ExpiresAt.IsZero() {
+		// The broker discards messages past their expiration, in milliseconds from publishing.
+		p.Expiration = strconv.FormatInt(max(time.Until(msg.ExpiresAt).Milliseconds(), 0), 10)
+	}
+	return p
+}
+
+// Ping publishes an empty message to the default exchange without a routing key, which the
+// broker accepts and discards, so it fails when the connection is lost.
+func (t *amqpTransport) Ping(ctx context.Context) error {
+	ch, err := t.channel()
+	if err != nil {
+		return err
+	}
+	return ch.PublishWithContext(ctx, "", "", false, false, amqp.Publishing{})
+}
+
+// Close closes the channel, and the connection when the transport dialed it.
+func (t *amqpTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	err := t.ch.Close()
+	if t.url != "" {
+		err = errors.Join(err, t.conn.Close())
+	}
+	if errors.Is(err, amqp.ErrClosed) {
+		return nil
+	}
+	return err
+}
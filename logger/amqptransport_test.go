@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPublishing(t *testing.T) {
+	p := publishing(Message{
+		Destination: "logs",
+		ContentType: EncodingProtobuf.ContentType(),
+		Headers:     map[string]string{"x-level": "error"},
+		Body:        []byte("body"),
+		ExpiresAt:   time.Now().Add(time.Minute),
+		ID:          "msg-1",
+	})
+
+	if p.ContentType != "application/x-protobuf" || p.MessageId != "msg-1" || string(p.Body) != "body" {
+		t.Errorf("unexpected publishing %+v", p)
+	}
+	if p.Headers["x-level"] != "error" {
+		t.Errorf("headers %v, want x-level", p.Headers)
+	}
+	if ms, err := strconv.Atoi(p.Expiration); err != nil || ms <= 0 || ms > 60000 {
+		t.Errorf("expiration %q, want at most a minute", p.Expiration)
+	}
+
+	if p := publishing(Message{ExpiresAt: time.Now().Add(-time.Minute)}); p.Expiration != "0" {
+		t.Errorf("expiration %q of an expired message, want 0", p.Expiration)
+	}
+	if p := publishing(Message{}); p.Expiration != "" || p.Headers != nil {
+		t.Errorf("unexpected properties %+v of a message without TTL nor headers", p)
+	}
+}
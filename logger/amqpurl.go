@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// URLOption configures the URL built by AMQPURL.
+type URLOption func(u *url.URL, query url.Values)
+
+// URLWithTLS switches the URL to amqps; the default port becomes 5671.
+func URLWithTLS() URLOption {
+	return func(u *url.URL, query url.Values) {
+		u.Scheme = "amqps"
+	}
+}
+
+// URLHeartbeat sets the heartbeat interval negotiated with the broker.
+func URLHeartbeat(interval time.Duration) URLOption {
+	return func(u *url.URL, query url.Values) {
+		query.Set("heartbeat", strconv.Itoa(int(interval/time.Second)))
+	}
+}
+
+// URLConnectionTimeout sets the timeout of the TCP connection to the broker.
+func URLConnectionTimeout(timeout time.Duration) URLOption {
+	return func(u *url.URL, query url.Values) {
+		query.Set("connection_timeout", strconv.FormatInt(timeout.Milliseconds(), 10))
+	}
+}
+
+// AMQPURL builds an AMQP URL with the user, password and vhost escaped, so passwords with
+// special characters ('@', '/', '%', ...) and vhosts such as "/" reach the broker intact.
+// An empty port defaults to 5672 (5671 with URLWithTLS), an empty vhost to the default "/".
+// The rabbitmq client is an external module, so the builder lives here; its result is passed
+// to rabbitmq.NewRabbitMQ, consumers.Config.URL or Config.BrokerURL.
+//
+// Usage:
+//
+//	rabbitMQ, err := rabbitmq.NewRabbitMQ(logger.AMQPURL("rabbitmq", "", "logger", "p@ss/w0rd", "mybazar",
+//		logger.URLHeartbeat(10*time.Second)), nil)
+func AMQPURL(host, port, user, pass, vhost string, opts ...URLOption) string {
+	u := &url.URL{Scheme: "amqp"}
+	query := url.Values{}
+	for _, opt := range opts {
+		opt(u, query)
+	}
+
+	if port == "" {
+		port = "5672"
+		if u.Scheme == "amqps" {
+			port = "5671"
+		}
+	}
+	if vhost == "" {
+		vhost = "/"
+	}
+	if user != "" || pass != "" {
+		u.User = url.UserPassword(user, pass)
+	}
+	u.Host = net.JoinHostPort(host, port)
+	u.Path = "/" + vhost
+	u.RawPath = "/" + url.PathEscape(vhost)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,497 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrLogDropped is returned by the level methods in async mode when the buffer is full
+// and the log was dropped according to the drop policy.
+var ErrLogDropped = errors.New("log buffer is full, log dropped")
+
+// ErrLoggerClosed is returned when logging through a closed async logger.
+var ErrLoggerClosed = errors.New("logger is closed")
+
+// DropPolicy decides what happens to a log when the async buffer is full.
+type DropPolicy int
+
+// Drop policies of the async mode.
+const (
+	DropNewest       DropPolicy = iota // Discard the log being added.
+	DropOldest                         // Discard the oldest buffered log to make room for the new one.
+	BlockWithTimeout                   // Wait up to AsyncConfig.BlockTimeout for room, then discard the new log.
+)
+
+// AsyncConfig holds the settings of the async mode.
+type AsyncConfig struct {
+	BufferSize   int           // Number of logs buffered while waiting to be published. Defaults to 8192.
+	Policy       DropPolicy    // What to do when the buffer is full. Defaults to DropNewest.
+	BlockTimeout time.Duration // Maximal wait of the BlockWithTimeout policy. Defaults to 100ms.
+
+	// Flush triggers: a batch is published as soon as one of them fires. Transports implementing
+	// BatchTransport publish a batch in one round trip, others message by message.
+	MaxBatchSize  int           // Maximal number of logs in a batch. Defaults to 1, publishing every log on its own.
+	MaxBatchBytes int           // Encoded size at which a batch is published before it is full; zero disables the trigger.
+	MaxBatchAge   time.Duration // Maximal time a log waits in an incomplete batch. Defaults to 100ms for batches above one log.
+
+	// Publishers is the number of goroutines publishing in parallel, each with its own share
+	// of the buffer. Defaults to 1.
+	Publishers int
+
+	// OrderingKey returns the key of logs that must stay in order, e.g. the merchant API key or an
+	// order ID. Logs with the same key are always published by the same goroutine, in the order they
+	// were logged. Logs with an empty key, or all logs when nil, are spread over the publishers.
+	OrderingKey func(log LogRequest) string
+
+	// MaxBufferedBytes caps the estimated memory of the buffered logs, so a long broker outage
+	// cannot exhaust the memory of the service. Above it the oldest buffered Info logs are
+	// evicted first; logs that do not fit even then are dropped. Zero disables the budget.
+	MaxBufferedBytes int64
+
+	// DeferMarshal keeps structured request payloads as they are until a publisher goroutine
+	// encodes the log, taking the marshaling off the caller and skipping it for dropped logs.
+	// The payload must not be modified after the level method returns; marshaling errors
+	// are then reported to OnError instead of the caller.
+	DeferMarshal bool
+
+	// OnError is called with the errors of logs published in the background; optional.
+	OnError func(err error)
+}
+
+// asyncQueue buffers logs and publishes them from background goroutines, one per shard.
+// Producers never take a lock: logs go through lock-free rings, and a goroutine is only
+// woken through a channel when it went to sleep on an empty ring.
+type asyncQueue struct {
+	config   AsyncConfig   // Async settings with defaults applied.
+	logger   *logger       // Logger encoding and publishing the logs.
+	shards   []*asyncShard // Publishing shards.
+	closed   atomic.Bool   // Set once the queue stops accepting logs.
+	inflight atomic.Int64  // Number of enqueues in progress, waited for by close.
+	dropped  atomic.Uint64 // Number of logs dropped because the buffer was full.
+	used     atomic.Int64  // Estimated memory of the buffered logs, tracked under a memory budget.
+}
+
+// asyncShard is a ring with the goroutine publishing its logs.
+type asyncShard struct {
+	queue    *asyncQueue        // Queue the shard belongs to.
+	index    int                // Position of the shard in the queue.
+	ring     *ring              // Logs waiting to be published.
+	sleeping atomic.Bool        // Set while the goroutine waits for logs.
+	wake     chan struct{}      // Wakes the sleeping goroutine.
+	space    chan struct{}      // Signals producers blocked on a full ring that logs were taken.
+	flushes  chan chan struct{} // Flush requests, closed once the logs enqueued before them are published.
+	done     chan struct{}      // Closed when the goroutine exits.
+	infoMu   sync.Mutex         // Protects infos.
+	infos    []*LogRecord       // Buffered Info logs from the oldest, evicted first under a memory budget.
+}
+
+// asyncBatch holds the encoded logs waiting for a flush trigger.
+type asyncBatch struct {
+	logs  []*LogRecord    // Logs of the batch.
+	msgs  []Message       // Encoded messages of the logs.
+	bufs  []*encodeBuffer // Buffers holding the message bodies.
+	bytes int             // Total size of the message bodies.
+}
+
+// WithAsync makes the level methods enqueue logs into a bounded buffer and return immediately;
+// background goroutines publish them, so a slow broker never adds latency to the caller.
+// When the buffer is full the drop policy applies and the level method returns ErrLogDropped.
+// Call Close before the process exits to publish the buffered logs.
+// Order messages are still published synchronously.
+func WithAsync(config AsyncConfig) Option {
+	return func(l *logger) {
+		if config.BufferSize <= 0 {
+			config.BufferSize = 8192
+		}
+		if config.BlockTimeout <= 0 {
+			config.BlockTimeout = 100 * time.Millisecond
+		}
+		if config.MaxBatchSize <= 0 {
+			config.MaxBatchSize = 1
+		}
+		if config.MaxBatchAge <= 0 && config.MaxBatchSize > 1 {
+			config.MaxBatchAge = 100 * time.Millisecond
+		}
+		if config.Publishers <= 0 {
+			config.Publishers = 1
+		}
+
+		q := &asyncQueue{config: config, logger: l}
+		for i := range config.Publishers {
+			shard := &asyncShard{
+				queue:   q,
+				index:   i,
+				ring:    newRing((config.BufferSize + config.Publishers - 1) / config.Publishers),
+				wake:    make(chan struct{}, 1),
+				space:   make(chan struct{}, 1),
+				flushes: make(chan chan struct{}),
+				done:    make(chan struct{}),
+			}
+			q.shards = append(q.shards, shard)
+			go shard.run()
+		}
+		l.async = q
+	}
+}
+
+// shard returns the shard publishing the log.
+func (q *asyncQueue) shard(log LogRequest) *asyncShard {
+	if len(q.shards) == 1 {
+		return q.shards[0]
+	}
+
+	if q.config.OrderingKey != nil {
+		if key := q.config.OrderingKey(log); key != "" {
+			h := fnv.New32a()
+			h.Write([]byte(key))
+			return q.shards[h.Sum32()%uint32(len(q.shards))]
+		}
+	}
+	return q.shards[rand.IntN(len(q.shards))]
+}
+
+// enqueue adds the log to the buffer of its shard, applying the drop policy when it is full.
+// The queue takes ownership of the log; source is the log as passed to the level method.
+func (q *asyncQueue) enqueue(log *LogRecord, source LogRequest) error {
+	shard := q.shard(source)
+
+	q.inflight.Add(1)
+	err := shard.push(log)
+	q.inflight.Add(-1)
+
+	if q.closed.Load() {
+		// Every shard waits for the enqueues in progress before exiting.
+		q.wakeAll()
+	} else {
+		shard.notify()
+	}
+	return err
+}
+
+// wakeAll wakes the goroutines of all shards.
+func (q *asyncQueue) wakeAll() {
+	for _, s := range q.shards {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// push adds the log to the ring according to the drop policy.
+func (s *asyncShard) push(log *LogRecord) error {
+	q := s.queue
+	if q.closed.Load() {
+		putLogRequest(log)
+		return ErrLoggerClosed
+	}
+
+	if q.budgeted() {
+		if !q.admit(s, log) {
+			putLogRequest(log)
+			q.dropped.Add(1)
+			return ErrLogDropped
+		}
+		if log.ErrorLevel == "info" {
+			s.register(log)
+		}
+	}
+
+	if s.ring.push(log) {
+		return nil
+	}
+
+	switch q.config.Policy {
+	case DropOldest:
+		for !s.ring.push(log) {
+			if oldest, ok := s.ring.pop(); ok {
+				s.discard(oldest)
+			}
+		}
+		return nil
+	case BlockWithTimeout:
+		if s.pushBlocking(log) {
+			return nil
+		}
+	}
+
+	s.discard(log)
+	return ErrLogDropped
+}
+
+// pushBlocking waits up to the block timeout for room in the ring, reporting false on timeout.
+func (s *asyncShard) pushBlocking(log *LogRecord) bool {
+	timer := time.NewTimer(s.queue.config.BlockTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.space:
+			if s.ring.push(log) {
+				// Pass the signal on to the next blocked producer.
+				s.signalSpace()
+				return true
+			}
+		case <-timer.C:
+			return false
+		}
+	}
+}
+
+// notify wakes the goroutine if it sleeps.
+func (s *asyncShard) notify() {
+	if s.sleeping.Load() && s.sleeping.CompareAndSwap(true, false) {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// signalSpace tells a producer blocked on a full ring that there is room.
+func (s *asyncShard) signalSpace() {
+	if s.queue.config.Policy != BlockWithTimeout {
+		return
+	}
+	select {
+	case s.space <- struct{}{}:
+	default:
+	}
+}
+
+// run batches and publishes the logs of the shard until the queue is closed and drained.
+func (s *asyncShard) run() {
+	defer close(s.done)
+
+	q := s.queue
+	var batch asyncBatch
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+
+	for {
+		for {
+			log, ok := s.ring.pop()
+			if !ok {
+				break
+			}
+			s.signalSpace()
+			if !s.take(log) {
+				continue
+			}
+			q.add(&batch, log)
+			if len(batch.logs) == 1 && q.config.MaxBatchAge > 0 {
+				timer.Reset(q.config.MaxBatchAge)
+			}
+			if q.full(&batch) {
+				timer.Stop()
+				q.flush(&batch)
+			}
+			// Under sustained load the goroutine never sleeps, so flush requests are checked here too.
+			select {
+			case flushed := <-s.flushes:
+				s.flushRequested(&batch, timer, flushed)
+			default:
+			}
+		}
+
+		if q.closed.Load() && q.inflight.Load() == 0 && s.ring.len() == 0 {
+			timer.Stop()
+			q.flush(&batch)
+			return
+		}
+
+		// Announce the sleep before checking the ring once more, so a log pushed
+		// in between either is seen here or wakes the goroutine.
+		s.sleeping.Store(true)
+		if s.ring.len() > 0 || q.closed.Load() && q.inflight.Load() == 0 {
+			s.sleeping.Store(false)
+			continue
+		}
+
+		select {
+		case <-s.wake:
+		case <-timer.C:
+			q.flush(&batch)
+		case flushed := <-s.flushes:
+			s.flushRequested(&batch, timer, flushed)
+		}
+		s.sleeping.Store(false)
+	}
+}
+
+// flushRequested publishes the logs enqueued before a Flush call and closes its channel.
+func (s *asyncShard) flushRequested(batch *asyncBatch, timer *time.Timer, flushed chan struct{}) {
+	q := s.queue
+	for n := s.ring.len(); n > 0; n-- {
+		log, ok := s.ring.pop()
+		if !ok {
+			break
+		}
+		s.signalSpace()
+		if !s.take(log) {
+			continue
+		}
+		q.add(batch, log)
+		if q.full(batch) {
+			q.flush(batch)
+		}
+	}
+	timer.Stop()
+	q.flush(batch)
+	close(flushed)
+}
+
+// add encodes the log into the batch. Logs failing to encode are reported and only reach the sinks.
+func (q *asyncQueue) add(batch *asyncBatch, log *LogRecord) {
+	msg, buf, err := q.logger.prepare(log)
+	if err != nil {
+		q.reportError(err)
+		q.logger.writeSinks(*log)
+		q.release(log)
+		return
+	}
+
+	batch.logs = append(batch.logs, log)
+	batch.msgs = append(batch.msgs, msg)
+	batch.bufs = append(batch.bufs, buf)
+	batch.bytes += len(msg.Body)
+}
+
+// full reports whether the batch reached its size or bytes trigger.
+func (q *asyncQueue) full(batch *asyncBatch) bool {
+	return len(batch.logs) >= q.config.MaxBatchSize ||
+		q.config.MaxBatchBytes > 0 && batch.bytes >= q.config.MaxBatchBytes
+}
+
+// flush publishes the batch, hands its logs to the sinks and empties it.
+func (q *asyncQueue) flush(batch *asyncBatch) {
+	if len(batch.logs) == 0 {
+		return
+	}
+
+	if bt, ok := q.logger.transport.(BatchTransport); ok && len(batch.msgs) > 1 {
+		err := q.logger.withRetry(len(batch.msgs), func() error { return bt.PublishBatch(batch.msgs) })
+		q.logger.stats.record(err, len(batch.msgs))
+		q.reportError(err)
+	} else {
+		for _, msg := range batch.msgs {
+			q.reportError(q.logger.deliver(msg))
+		}
+	}
+
+	for i, log := range batch.logs {
+		putEncodeBuffer(batch.bufs[i])
+		q.logger.writeSinks(*log)
+		q.release(log)
+		batch.logs[i], batch.bufs[i], batch.msgs[i] = nil, nil, Message{}
+	}
+	batch.logs, batch.msgs, batch.bufs, batch.bytes = batch.logs[:0], batch.msgs[:0], batch.bufs[:0], 0
+}
+
+// reportError passes the error to the OnError callback, if any.
+func (q *asyncQueue) reportError(err error) {
+	if err != nil && q.config.OnError != nil {
+		q.config.OnError(err)
+	}
+}
+
+// flushAll waits until the logs enqueued so far are published or the context is done.
+func (q *asyncQueue) flushAll(ctx context.Context) error {
+	pending := make([]chan struct{}, 0, len(q.shards))
+	for _, s := range q.shards {
+		flushed := make(chan struct{})
+		select {
+		case s.flushes <- flushed:
+			pending = append(pending, flushed)
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for _, flushed := range pending {
+		select {
+		case <-flushed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// close stops accepting logs and waits until the buffered ones are published or the context is done.
+func (q *asyncQueue) close(ctx context.Context) error {
+	q.closed.Store(true)
+	q.wakeAll()
+
+	for _, s := range q.shards {
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// wait waits until the goroutines of all shards have exited.
+func (q *asyncQueue) wait() {
+	for _, s := range q.shards {
+		<-s.done
+	}
+}
+
+// Dropped returns the number of logs dropped because the async buffer was full.
+// It is always zero for loggers not in async mode.
+func (l *logger) Dropped() uint64 {
+	if l.async == nil {
+		return 0
+	}
+	return l.async.dropped.Load()
+}
+
+// Flush publishes the logs buffered in async mode, including incomplete batches, and waits
+// until they are delivered or the context is done. It is a no-op for loggers not in async mode.
+func (l *logger) Flush(ctx context.Context) error {
+	if l.async == nil {
+		return nil
+	}
+	return l.async.flushAll(ctx)
+}
+
+// Close publishes the logs buffered in async mode and closes the attached sinks.
+// It waits until everything is delivered or the context is done. When the context is done
+// first, the buffered logs are still published in the background and the sinks are only
+// closed afterwards, since the publishing goroutines keep handing logs to them. The transport
+// is owned by the caller and is left open.
+func (l *logger) Close(ctx context.Context) error {
+	if l.report != nil {
+		l.report.close()
+	}
+
+	if l.async != nil {
+		if err := l.async.close(ctx); err != nil {
+			go func() {
+				l.async.wait()
+				_ = l.closeSinks()
+			}()
+			return err
+		}
+	}
+	return l.closeSinks()
+}
+
+// closeSinks closes the attached sinks.
+func (l *logger) closeSinks() error {
+	var errs []error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
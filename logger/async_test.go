@@ -0,0 +1,230 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// gateTransport is a captureTransport whose publishes wait until the gate is opened.
+type gateTransport struct {
+	captureTransport
+	started chan struct{} // Receives a value when a publish starts waiting.
+	gate    chan struct{} // Closed to let the publishes through.
+}
+
+func newGateTransport() *gateTransport {
+	return &gateTransport{started: make(chan struct{}, 100), gate: make(chan struct{})}
+}
+
+func (t *gateTransport) Publish(msg logger.Message) error {
+	t.started <- struct{}{}
+	<-t.gate
+	return t.captureTransport.Publish(msg)
+}
+
+// messages returns the error messages of the published logs, oldest first.
+func messages(t *testing.T, transport *captureTransport) []string {
+	t.Helper()
+	var messages []string
+	for _, body := range transport.bodies() {
+		d, err := consumers.Decode(body)
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		messages = append(messages, d.Record.ErrorMessage)
+	}
+	return messages
+}
+
+// info logs an Info log with the error message.
+func info(log logger.Logger, message string) error {
+	return log.Info(logger.LogRequest{Errorcode: logger.InfoRequestProcessed, ClientMessageUz: "Tayyor", ErrorMessage: message})
+}
+
+// fillAsync creates an async logger with a buffer of two logs and fills it while the first
+// log waits for the gate of the transport.
+func fillAsync(t *testing.T, transport *gateTransport, config logger.AsyncConfig) logger.Logger {
+	t.Helper()
+	config.BufferSize = 2
+	log, err := logger.NewLoggerWithTransport(transport, "logs", "Import", "/import", nil, nil, logger.WithAsync(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := info(log, "1"); err != nil {
+		t.Fatal(err)
+	}
+	<-transport.started
+	for _, message := range []string{"2", "3"} {
+		if err := info(log, message); err != nil {
+			t.Fatalf("log %s: %v", message, err)
+		}
+	}
+	return log
+}
+
+func TestAsyncDropNewest(t *testing.T) {
+	transport := newGateTransport()
+	log := fillAsync(t, transport, logger.AsyncConfig{Policy: logger.DropNewest})
+
+	if err := info(log, "4"); !errors.Is(err, logger.ErrLogDropped) {
+		t.Errorf("log on a full buffer: %v, want ErrLogDropped", err)
+	}
+	close(transport.gate)
+	if err := log.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := messages(t, &transport.captureTransport), []string{"1", "2", "3"}; !slices.Equal(got, want) {
+		t.Errorf("published %q, want %q", got, want)
+	}
+	if n := log.Dropped(); n != 1 {
+		t.Errorf("Dropped() = %d, want 1", n)
+	}
+}
+
+func TestAsyncDropOldest(t *testing.T) {
+	transport := newGateTransport()
+	log := fillAsync(t, transport, logger.AsyncConfig{Policy: logger.DropOldest})
+
+	if err := info(log, "4"); err != nil {
+		t.Errorf("log on a full buffer: %v", err)
+	}
+	close(transport.gate)
+	if err := log.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := messages(t, &transport.captureTransport), []string{"1", "3", "4"}; !slices.Equal(got, want) {
+		t.Errorf("published %q, want %q", got, want)
+	}
+	if n := log.Dropped(); n != 1 {
+		t.Errorf("Dropped() = %d, want 1", n)
+	}
+}
+
+func TestAsyncBlockWithTimeout(t *testing.T) {
+	transport := newGateTransport()
+	log := fillAsync(t, transport, logger.AsyncConfig{Policy: logger.BlockWithTimeout, BlockTimeout: 20 * time.Millisecond})
+
+	start := time.Now()
+	if err := info(log, "4"); !errors.Is(err, logger.ErrLogDropped) {
+		t.Errorf("log on a full buffer: %v, want ErrLogDropped", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("dropped after %s, before the block timeout", elapsed)
+	}
+
+	close(transport.gate)
+	if err := log.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := messages(t, &transport.captureTransport), []string{"1", "2", "3"}; !slices.Equal(got, want) {
+		t.Errorf("published %q, want %q", got, want)
+	}
+	if n := log.Dropped(); n != 1 {
+		t.Errorf("Dropped() = %d, want 1", n)
+	}
+}
+
+func TestAsyncBlockWithTimeoutWaitsForRoom(t *testing.T) {
+	transport := newGateTransport()
+	log := fillAsync(t, transport, logger.AsyncConfig{Policy: logger.BlockWithTimeout, BlockTimeout: 5 * time.Second})
+
+	time.AfterFunc(20*time.Millisecond, func() { close(transport.gate) })
+	if err := info(log, "4"); err != nil {
+		t.Errorf("log on a full buffer: %v", err)
+	}
+	if err := log.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := messages(t, &transport.captureTransport), []string{"1", "2", "3", "4"}; !slices.Equal(got, want) {
+		t.Errorf("published %q, want %q", got, want)
+	}
+	if n := log.Dropped(); n != 0 {
+		t.Errorf("Dropped() = %d, want 0", n)
+	}
+}
+
+func TestAsyncFlush(t *testing.T) {
+	transport := &captureTransport{}
+	log, err := logger.NewLoggerWithTransport(transport, "logs", "Import", "/import", nil, nil,
+		logger.WithAsync(logger.AsyncConfig{MaxBatchSize: 100, MaxBatchAge: time.Hour}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close(context.Background())
+
+	for i := range 3 {
+		if err := info(log, fmt.Sprint(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The batch is neither full nor old enough to be published on its own.
+	if err := log.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := messages(t, transport), []string{"0", "1", "2"}; !slices.Equal(got, want) {
+		t.Errorf("published %q after Flush, want %q", got, want)
+	}
+}
+
+func TestAsyncCloseDrains(t *testing.T) {
+	const producers, perProducer = 8, 250
+	transport := &captureTransport{}
+	log, err := logger.NewLoggerWithTransport(transport, "logs", "Import", "/import", nil, nil,
+		logger.WithAsync(logger.AsyncConfig{
+			BufferSize:   64,
+			Policy:       logger.BlockWithTimeout,
+			BlockTimeout: 10 * time.Second,
+			MaxBatchSize: 16,
+			MaxBatchAge:  time.Hour,
+			Publishers:   4,
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				if err := info(log, fmt.Sprintf("%d-%d", p, i)); err != nil {
+					t.Errorf("log %d-%d: %v", p, i, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := log.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	got := messages(t, transport)
+	if len(got) != producers*perProducer {
+		t.Errorf("published %d logs, want %d", len(got), producers*perProducer)
+	}
+	slices.Sort(got)
+	if len(slices.Compact(got)) != producers*perProducer {
+		t.Error("logs published more than once")
+	}
+	if n := log.Dropped(); n != 0 {
+		t.Errorf("Dropped() = %d, want 0", n)
+	}
+	if err := info(log, "late"); !errors.Is(err, logger.ErrLoggerClosed) {
+		t.Errorf("log after Close: %v, want ErrLoggerClosed", err)
+	}
+}
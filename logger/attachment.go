@@ -0,0 +1,30 @@
+package logger
+
+// Attachment is a file published with a log, see LogRequest.Attachments. Its data is base64
+// encoded in JSON and raw bytes in the binary encodings; with WithPayloadOffload, data larger
+// than the threshold is uploaded and replaced with Ref.
+//
+// Usage:
+//
+//	err := log.Info(logger.LogRequest{
+//		Errorcode:       logger.InfoRequestProcessed,
+//		ClientMessageUz: "Chek saqlandi",
+//		Attachments: []logger.Attachment{
+//			{Name: "receipt-1042.pdf", ContentType: "application/pdf", Data: receipt},
+//		},
+//	})
+type Attachment struct {
+	Name        string   `json:"name"`                   // File name, e.g. "receipt-1042.pdf".
+	ContentType string   `json:"content_type,omitempty"` // MIME type of the data.
+	Data        []byte   `json:"data,omitempty"`         // Content of the file; empty when offloaded.
+	Ref         *BlobRef `json:"ref,omitempty"`          // Offloaded content, see WithPayloadOffload.
+}
+
+// attachmentsSize returns the memory held by the attachments.
+func attachmentsSize(attachments []Attachment) int {
+	size := 0
+	for _, a := range attachments {
+		size += len(a.Name) + len(a.ContentType) + len(a.Data)
+	}
+	return size
+}
@@ -0,0 +1,320 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AvroSchema is the Avro schema of published logs. Optional fields default to empty values,
+// so fields can be added later without breaking readers of older records.
+const AvroSchema = `{
+  "type": "record",
+  "name": "Log",
+  "namespace": "mybazar.logger.v1",
+  "fields": [
+    {"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+    {"name": "error_level", "type": "string"},
+    {"name": "error_code", "type": "int"},
+    {"name": "client_message_uz", "type": "string"},
+    {"name": "client_message_ru", "type": "string"},
+    {"name": "error_message", "type": "string"},
+    {"name": "details_uz", "type": "string", "default": ""},
+    {"name": "details_ru", "type": "string", "default": ""},
+    {"name": "api_endpoint", "type": "string"},
+    {"name": "method", "type": "string"},
+    {"name": "function_name", "type": "string"},
+    {"name": "status_code", "type": "int"},
+    {"name": "request_payload", "type": "string"},
+    {"name": "event_type", "type": "string"},
+    {"name": "response_data", "type": "string", "default": ""},
+    {"name": "merchant_api_key", "type": "string", "default": ""},
+    {"name": "duration_ms", "type": "long", "default": 0},
+    {"name": "request_payload_ref", "default": null, "type": ["null", {
+      "type": "record",
+      "name": "BlobRef",
+      "fields": [
+        {"name": "bucket", "type": "string"},
+        {"name": "key", "type": "string"},
+        {"name": "size", "type": "long"},
+        {"name": "sha256", "type": "string"}
+      ]
+    }]},
+    {"name": "response_data_ref", "type": ["null", "BlobRef"], "default": null},
+    {"name": "request_payload_encoding", "type": "string", "default": ""},
+    {"name": "response_data_encoding", "type": "string", "default": ""},
+    {"name": "validation_failed", "type": "boolean", "default": false},
+    {"name": "validation_error", "type": "string", "default": ""},
+    {"name": "producer_id", "type": "string", "default": ""},
+    {"name": "sequence", "type": "long", "default": 0},
+    {"name": "schema_version", "type": "int", "default": 0},
+    {"name": "host", "type": "string", "default": ""},
+    {"name": "pod", "type": "string", "default": ""},
+    {"name": "service", "type": "string", "default": ""},
+    {"name": "instance_id", "type": "string", "default": ""},
+    {"name": "environment", "type": "string", "default": ""},
+    {"name": "app_version", "type": "string", "default": ""},
+    {"name": "git_commit", "type": "string", "default": ""},
+    {"name": "user_id", "type": "string", "default": ""},
+    {"name": "session_id", "type": "string", "default": ""},
+    {"name": "client_ip", "type": "string", "default": ""},
+    {"name": "user_agent", "type": "string", "default": ""},
+    {"name": "request_id", "type": "string", "default": ""},
+    {"name": "errors", "default": [], "type": {"type": "array", "items": {
+      "type": "record",
+      "name": "ErrorDetail",
+      "fields": [
+        {"name": "code", "type": "int"},
+        {"name": "message", "type": "string"}
+      ]
+    }}},
+    {"name": "extra", "type": "string", "default": ""},
+    {"name": "expires_at", "type": ["null", {"type": "long", "logicalType": "timestamp-micros"}], "default": null},
+    {"name": "message_id", "type": "string", "default": ""},
+    {"name": "attachments", "default": [], "type": {"type": "array", "items": {
+      "type": "record",
+      "name": "Attachment",
+      "fields": [
+        {"name": "name", "type": "string"},
+        {"name": "content_type", "type": "string"},
+        {"name": "data", "type": "bytes"},
+        {"name": "ref", "type": ["null", "BlobRef"], "default": null}
+      ]
+    }}},
+    {"name": "group_id", "type": "string", "default": ""}
+  ]
+}`
+
+// AvroConfig holds the settings of the Avro encoding.
+type AvroConfig struct {
+	RegistryURL string       // Base URL of the Confluent-compatible schema registry.
+	Subject     string       // Subject the schema is registered under. Defaults to "<queue>-value".
+	Username    string       // Basic auth user of the registry; optional.
+	Password    string       // Basic auth password of the registry; optional.
+	HTTPClient  *http.Client // HTTP client used for registry requests. Defaults to a client with a 10s timeout.
+}
+
+// avroEncoder encodes logs as Confluent-framed Avro records.
+type avroEncoder struct {
+	config   AvroConfig // Avro settings with defaults applied.
+	mu       sync.Mutex // Protects schemaID and resolved.
+	schemaID uint32     // Registry ID of AvroSchema.
+	resolved bool       // Set once the schema is registered.
+}
+
+// WithAvro publishes logs as Avro records of AvroSchema in the Confluent wire format
+// (a zero magic byte and the 4-byte schema ID before the record), for pipelines reading
+// the stream with a schema registry. The schema is registered on the first published log;
+// registry failures are returned by the level methods and retried on the next log.
+// Order messages are always published as JSON, and consumers.Decode does not read Avro.
+func WithAvro(config AvroConfig) Option {
+	return func(l *logger) {
+		if config.Subject == "" {
+			config.Subject = l.queue + "-value"
+		}
+		if config.HTTPClient == nil {
+			config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+		}
+		l.encoding = EncodingAvro
+		l.avro = &avroEncoder{config: config}
+	}
+}
+
+// encode appends the framed Avro record of the log to dst.
+func (e *avroEncoder) encode(dst []byte, v any) ([]byte, error) {
+	log, ok := v.(*LogRecord)
+	if !ok {
+		return nil, fmt.Errorf("avro encoding is not supported for %T", v)
+	}
+
+	extra, err := extraJSON(log.Extra)
+	if err != nil {
+		return nil, err
+	}
+	id, err := e.id()
+	if err != nil {
+		return nil, err
+	}
+
+	dst = append(dst, 0)
+	dst = binary.BigEndian.AppendUint32(dst, id)
+	dst = appendAvroLong(dst, log.Timestamp.UnixMicro())
+	dst = appendAvroString(dst, log.ErrorLevel)
+	dst = appendAvroLong(dst, int64(log.Errorcode))
+	dst = appendAvroString(dst, log.ClientMessageUz)
+	dst = appendAvroString(dst, log.ClientMessageRu)
+	dst = appendAvroString(dst, log.ErrorMessage)
+	dst = appendAvroString(dst, log.DetailsUz)
+	dst = appendAvroString(dst, log.DetailsRu)
+	dst = appendAvroString(dst, log.ApiEndpoint)
+	dst = appendAvroString(dst, log.Method)
+	dst = appendAvroString(dst, log.FunctionName)
+	dst = appendAvroLong(dst, int64(log.StatusCode))
+	dst = appendAvroString(dst, log.RequestPayload)
+	dst = appendAvroString(dst, log.EventType)
+	dst = appendAvroString(dst, log.ResponseData)
+	dst = appendAvroString(dst, log.MerchantApiKey)
+	dst = appendAvroLong(dst, log.DurationMs)
+	dst = appendAvroBlobRef(dst, log.RequestPayloadRef)
+	dst = appendAvroBlobRef(dst, log.ResponseDataRef)
+	dst = appendAvroString(dst, log.RequestPayloadEncoding)
+	dst = appendAvroString(dst, log.ResponseDataEncoding)
+	dst = appendAvroBool(dst, log.ValidationFailed)
+	dst = appendAvroString(dst, log.ValidationError)
+	dst = appendAvroString(dst, log.ProducerID)
+	dst = appendAvroLong(dst, int64(log.Sequence))
+	dst = appendAvroLong(dst, int64(log.SchemaVersion))
+	dst = appendAvroString(dst, log.Host)
+	dst = appendAvroString(dst, log.Pod)
+	dst = appendAvroString(dst, log.Service)
+	dst = appendAvroString(dst, log.InstanceID)
+	dst = appendAvroString(dst, log.Environment)
+	dst = appendAvroString(dst, log.AppVersion)
+	dst = appendAvroString(dst, log.GitCommit)
+	dst = appendAvroString(dst, log.UserID)
+	dst = appendAvroString(dst, log.SessionID)
+	dst = appendAvroString(dst, log.ClientIP)
+	dst = appendAvroString(dst, log.UserAgent)
+	dst = appendAvroString(dst, log.RequestID)
+	dst = appendAvroErrors(dst, log.Errors)
+	dst = appendAvroString(dst, extra)
+	dst = appendAvroTime(dst, log.ExpiresAt)
+	dst = appendAvroString(dst, log.MessageID)
+	dst = appendAvroAttachments(dst, log.Attachments)
+	dst = appendAvroString(dst, log.GroupID)
+	return dst, nil
+}
+
+// id returns the registry ID of the schema, registering it on first use.
+func (e *avroEncoder) id() (uint32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.resolved {
+		return e.schemaID, nil
+	}
+	id, err := e.register()
+	if err != nil {
+		return 0, fmt.Errorf("failed to register avro schema: %w", err)
+	}
+	e.schemaID, e.resolved = id, true
+	return id, nil
+}
+
+// register registers the schema under the subject. The registry returns the existing ID
+// when the schema is already registered.
+func (e *avroEncoder) register() (uint32, error) {
+	body, err := json.Marshal(map[string]string{"schema": AvroSchema})
+	if err != nil {
+		return 0, err
+	}
+
+	endpoint := fmt.Sprintf("%s/subjects/%s/versions", e.config.RegistryURL, url.PathEscape(e.config.Subject))
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	if e.config.Username != "" {
+		req.SetBasicAuth(e.config.Username, e.config.Password)
+	}
+
+	resp, err := e.config.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.New("schema registry returned status " + strconv.Itoa(resp.StatusCode) + ": " + string(data))
+	}
+
+	var result struct {
+		ID uint32 `json:"id"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return 0, err
+	}
+	return result.ID, nil
+}
+
+// appendAvroLong appends an Avro int or long: a zig-zag encoded varint.
+func appendAvroLong(dst []byte, v int64) []byte {
+	return binary.AppendUvarint(dst, uint64((v<<1)^(v>>63)))
+}
+
+// appendAvroBool appends an Avro boolean: a single 0 or 1 byte.
+func appendAvroBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, 1)
+	}
+	return append(dst, 0)
+}
+
+// appendAvroBlobRef appends an optional reference: the union branch (null or BlobRef) followed by its fields.
+func appendAvroBlobRef(dst []byte, ref *BlobRef) []byte {
+	if ref == nil {
+		return appendAvroLong(dst, 0)
+	}
+	dst = appendAvroLong(dst, 1)
+	dst = appendAvroString(dst, ref.Bucket)
+	dst = appendAvroString(dst, ref.Key)
+	dst = appendAvroLong(dst, ref.Size)
+	return appendAvroString(dst, ref.SHA256)
+}
+
+// appendAvroTime appends an optional timestamp: the union branch (null or long) followed by
+// the microseconds since the epoch.
+func appendAvroTime(dst []byte, t *time.Time) []byte {
+	if t == nil {
+		return appendAvroLong(dst, 0)
+	}
+	dst = appendAvroLong(dst, 1)
+	return appendAvroLong(dst, t.UnixMicro())
+}
+
+// appendAvroErrors appends the error details as an Avro array: a single block with its
+// item count, followed by the zero count ending the array.
+func appendAvroErrors(dst []byte, details []ErrorDetail) []byte {
+	if len(details) > 0 {
+		dst = appendAvroLong(dst, int64(len(details)))
+		for _, detail := range details {
+			dst = appendAvroLong(dst, int64(detail.Code))
+			dst = appendAvroString(dst, detail.Message)
+		}
+	}
+	return appendAvroLong(dst, 0)
+}
+
+// appendAvroAttachments appends the attachments as an Avro array, like appendAvroErrors.
+func appendAvroAttachments(dst []byte, attachments []Attachment) []byte {
+	if len(attachments) > 0 {
+		dst = appendAvroLong(dst, int64(len(attachments)))
+		for _, a := range attachments {
+			dst = appendAvroString(dst, a.Name)
+			dst = appendAvroString(dst, a.ContentType)
+			dst = appendAvroLong(dst, int64(len(a.Data)))
+			dst = append(dst, a.Data...)
+			dst = appendAvroBlobRef(dst, a.Ref)
+		}
+	}
+	return appendAvroLong(dst, 0)
+}
+
+// appendAvroString appends an Avro string: its length followed by the UTF-8 bytes.
+func appendAvroString(dst []byte, s string) []byte {
+	dst = appendAvroLong(dst, int64(len(s)))
+	return append(dst, s...)
+}
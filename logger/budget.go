@@ -0,0 +1,120 @@
+package logger
+
+import "sync/atomic"
+
+// States of a log buffered under a memory budget.
+const (
+	logQueued  uint32 = iota // Waiting in a ring.
+	logTaken                 // Taken out of the ring by a publisher or the drop policy.
+	logEvicted               // Evicted to respect the memory budget.
+)
+
+// logOverhead approximates the memory of a buffered log besides its strings.
+const logOverhead = 256
+
+// logSize estimates the memory held by a buffered log. Payloads whose marshaling is
+// deferred are not known yet and only count with the overhead.
+func logSize(log *LogRecord) int64 {
+	return int64(logOverhead + len(log.ErrorLevel) + len(log.ClientMessageUz) + len(log.ClientMessageRu) +
+		len(log.ErrorMessage) + len(log.DetailsUz) + len(log.DetailsRu) + len(log.ApiEndpoint) + len(log.Method) +
+		len(log.RequestPayload) + len(log.EventType) + len(log.ResponseData) + len(log.MerchantApiKey) +
+		len(log.UserID) + len(log.SessionID) + len(log.ClientIP) + len(log.UserAgent) + len(log.RequestID) + len(log.MessageID) + len(log.GroupID) + attachmentsSize(log.Attachments))
+}
+
+// budgeted reports whether the buffered logs are subject to a memory budget.
+func (q *asyncQueue) budgeted() bool {
+	return q.config.MaxBufferedBytes > 0
+}
+
+// admit reserves the memory of the log, evicting the oldest buffered Info logs of the shards
+// until it fits. It reports false when the log does not fit even without any Info log.
+func (q *asyncQueue) admit(s *asyncShard, log *LogRecord) bool {
+	log.size = logSize(log)
+	if q.used.Add(log.size) <= q.config.MaxBufferedBytes {
+		return true
+	}
+
+	// The shard of the log first, then the others.
+	for i := range q.shards {
+		other := q.shards[(s.index+i)%len(q.shards)]
+		for q.used.Load() > q.config.MaxBufferedBytes && other.evictInfo() {
+		}
+	}
+	if q.used.Load() <= q.config.MaxBufferedBytes {
+		return true
+	}
+
+	q.used.Add(-log.size)
+	return false
+}
+
+// register adds a buffered Info log to the eviction list of the shard.
+func (s *asyncShard) register(log *LogRecord) {
+	s.infoMu.Lock()
+	s.infos = append(s.infos, log)
+	s.infoMu.Unlock()
+}
+
+// evictInfo evicts the oldest buffered Info log of the shard, reporting false if there is none.
+// The evicted log stays in its ring until a publisher skips it, but its strings are released.
+func (s *asyncShard) evictInfo() bool {
+	s.infoMu.Lock()
+	defer s.infoMu.Unlock()
+
+	for len(s.infos) > 0 {
+		log := s.infos[0]
+		s.infos[0] = nil
+		s.infos = s.infos[1:]
+		if !atomic.CompareAndSwapUint32(&log.budgetState, logQueued, logEvicted) {
+			continue
+		}
+
+		s.queue.used.Add(-log.size)
+		s.queue.dropped.Add(1)
+		log.ClientMessageUz, log.ClientMessageRu, log.ErrorMessage, log.DetailsUz, log.DetailsRu = "", "", "", "", ""
+		log.RequestPayload, log.ResponseData, log.payload = "", "", nil
+		return true
+	}
+	return false
+}
+
+// take claims a log popped from the ring, reporting false if it was evicted meanwhile;
+// evicted logs are already accounted for and must be left alone.
+func (s *asyncShard) take(log *LogRecord) bool {
+	q := s.queue
+	if !q.budgeted() {
+		return true
+	}
+	if !atomic.CompareAndSwapUint32(&log.budgetState, logQueued, logTaken) {
+		return false
+	}
+
+	q.used.Add(-log.size)
+	if log.ErrorLevel == "info" {
+		// Taken logs at the front of the eviction list are useless; dropping them keeps it short.
+		s.infoMu.Lock()
+		for len(s.infos) > 0 && atomic.LoadUint32(&s.infos[0].budgetState) != logQueued {
+			s.infos[0] = nil
+			s.infos = s.infos[1:]
+		}
+		s.infoMu.Unlock()
+	}
+	return true
+}
+
+// release returns a taken log to the pool. Under a memory budget Info logs may still be
+// referenced by an eviction list, so they are left to the garbage collector.
+func (q *asyncQueue) release(log *LogRecord) {
+	if q.budgeted() && log.ErrorLevel == "info" {
+		return
+	}
+	putLogRequest(log)
+}
+
+// discard drops a log that could not be buffered.
+func (s *asyncShard) discard(log *LogRecord) {
+	if s.take(log) {
+		s.queue.dropped.Add(1)
+		s.queue.release(log)
+	}
+}
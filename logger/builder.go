@@ -0,0 +1,157 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// LogBuilder builds a LogRequest fluently. Create it with NewLog.
+type LogBuilder struct {
+	log LogRequest // Log being built.
+}
+
+// NewLog starts building a log with the error code.
+//
+// Usage:
+//
+//	req, err := logger.NewLog(logger.ErrInvalidData).
+//		ClientUz("Noto'g'ri ma'lumot").
+//		ClientRu("Неверные данные").
+//		Payload(body).
+//		Status(400).
+//		Build()
+//	if err == nil {
+//		err = log.Error(req)
+//	}
+func NewLog(code Errorcode) *LogBuilder {
+	return &LogBuilder{log: LogRequest{Errorcode: code}}
+}
+
+// ClientUz sets the client message in Uzbek.
+func (b *LogBuilder) ClientUz(message string) *LogBuilder {
+	b.log.ClientMessageUz = message
+	return b
+}
+
+// ClientRu sets the client message in Russian.
+func (b *LogBuilder) ClientRu(message string) *LogBuilder {
+	b.log.ClientMessageRu = message
+	return b
+}
+
+// Message sets the internal error message.
+func (b *LogBuilder) Message(message string) *LogBuilder {
+	b.log.ErrorMessage = message
+	return b
+}
+
+// Err sets the error behind the log, see LogRequest.Err.
+func (b *LogBuilder) Err(err error) *LogBuilder {
+	b.log.Err = err
+	return b
+}
+
+// Details sets the details in Uzbek and Russian.
+func (b *LogBuilder) Details(uz, ru string) *LogBuilder {
+	b.log.DetailsUz, b.log.DetailsRu = uz, ru
+	return b
+}
+
+// Endpoint sets the API endpoint.
+func (b *LogBuilder) Endpoint(endpoint string) *LogBuilder {
+	b.log.ApiEndpoint = endpoint
+	return b
+}
+
+// Method sets the HTTP method.
+func (b *LogBuilder) Method(method string) *LogBuilder {
+	b.log.Method = method
+	return b
+}
+
+// Status sets the status code.
+func (b *LogBuilder) Status(code int) *LogBuilder {
+	b.log.StatusCode = code
+	return b
+}
+
+// Payload sets the request payload.
+func (b *LogBuilder) Payload(payload any) *LogBuilder {
+	b.log.RequestPayload = payload
+	return b
+}
+
+// Response sets the response data.
+func (b *LogBuilder) Response(data string) *LogBuilder {
+	b.log.ResponseData = data
+	return b
+}
+
+// Event sets the event type.
+func (b *LogBuilder) Event(eventType EventType) *LogBuilder {
+	b.log.EventType = eventType
+	return b
+}
+
+// Merchant sets the merchant API key.
+func (b *LogBuilder) Merchant(apiKey string) *LogBuilder {
+	b.log.MerchantApiKey = apiKey
+	return b
+}
+
+// Duration sets the request duration.
+func (b *LogBuilder) Duration(d time.Duration) *LogBuilder {
+	b.log = b.log.WithDuration(d)
+	return b
+}
+
+// User sets the user and session.
+func (b *LogBuilder) User(userID, sessionID string) *LogBuilder {
+	b.log.UserID, b.log.SessionID = userID, sessionID
+	return b
+}
+
+// TTL sets the lifetime of the log, see LogRequest.TTL.
+func (b *LogBuilder) TTL(ttl time.Duration) *LogBuilder {
+	b.log.TTL = ttl
+	return b
+}
+
+// Extra adds a key to the extra metadata.
+func (b *LogBuilder) Extra(key string, value any) *LogBuilder {
+	if b.log.Extra == nil {
+		b.log.Extra = make(map[string]any)
+	}
+	b.log.Extra[key] = value
+	return b
+}
+
+// Request fills the fields taken from the HTTP request, see LogRequest.WithRequest.
+func (b *LogBuilder) Request(r *http.Request) *LogBuilder {
+	b.log = b.log.WithRequest(r)
+	return b
+}
+
+// Context fills the fields carried by the context, see LogRequest.WithContext.
+func (b *LogBuilder) Context(ctx context.Context) *LogBuilder {
+	b.log = b.log.WithContext(ctx)
+	return b
+}
+
+// Build returns the log, or a *ValidationError when mandatory fields are missing:
+// the error code and at least one client message.
+func (b *LogBuilder) Build() (LogRequest, error) {
+	var errs []error
+	if b.log.Errorcode == 0 {
+		errs = append(errs, ErrMissingErrorCode)
+	}
+	if b.log.ClientMessageUz == "" && b.log.ClientMessageRu == "" {
+		errs = append(errs, ErrMissingClientMessage)
+	}
+
+	if len(errs) > 0 {
+		return LogRequest{}, &ValidationError{Errors: errs}
+	}
+	return b.log, nil
+}
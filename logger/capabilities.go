@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"runtime/debug"
+	"strconv"
+	"sync"
+)
+
+// ModulePath is the module path of the logger, identifying it in the build info of producers.
+const ModulePath = "github.com/kupalovmuhammadjon/mybazar-logger"
+
+// Capability headers, added to every published message so consumers can audit the fleet for
+// out-of-date producers, see consumers.CheckCompatibility.
+const (
+	HeaderProducerVersion = "x-logger-version" // Version of the logger module, see PackageVersion.
+	HeaderSchemaVersion   = "x-schema-version" // Version of the log schema, see SchemaVersion.
+	HeaderEncoding        = "x-encoding"       // Wire format of the body, see Encoding.String.
+)
+
+// PackageVersion returns the version of the logger module the producer was built with, e.g.
+// "v1.8.0", read from the build info. It is "(devel)" when the version is unknown, e.g. for
+// builds of this repository itself or with a replace directive.
+var PackageVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == ModulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == ModulePath && dep.Replace == nil {
+			return dep.Version
+		}
+	}
+	return "(devel)"
+})
+
+// capabilityHeaders holds the capability headers of each encoding, shared by all messages.
+var capabilityHeaders = sync.OnceValue(func() map[Encoding]map[string]string {
+	headers := make(map[Encoding]map[string]string)
+	for _, encoding := range []Encoding{EncodingJSON, EncodingMsgPack, EncodingProtobuf, EncodingAvro} {
+		headers[encoding] = map[string]string{
+			HeaderProducerVersion: PackageVersion(),
+			HeaderSchemaVersion:   strconv.Itoa(SchemaVersion),
+			HeaderEncoding:        encoding.String(),
+		}
+	}
+	return headers
+})
@@ -0,0 +1,71 @@
+package logger
+
+//go:generate go run ../cmd/codegen -csv errorcodes.csv
+
+// Category is the group an error code belongs to, derived from its numeric range.
+type Category string
+
+// Error code categories.
+const (
+	CategoryValidation     Category = "validation"
+	CategoryAuthentication Category = "authentication"
+	CategoryResource       Category = "resource"
+	CategorySystem         Category = "system"
+	CategoryIntegration    Category = "integration"
+	CategoryBusiness       Category = "business"
+	CategoryInfo           Category = "info"
+	CategoryWarning        Category = "warning"
+	CategoryUnknown        Category = "unknown"
+)
+
+// Category returns the category of the error code based on its range (1xxx validation, 2xxx authentication, ...).
+func (c Errorcode) Category() Category {
+	switch {
+	case c >= 1000 && c < 2000:
+		return CategoryValidation
+	case c >= 2000 && c < 3000:
+		return CategoryAuthentication
+	case c >= 3000 && c < 4000:
+		return CategoryResource
+	case c >= 4000 && c < 5000:
+		return CategorySystem
+	case c >= 5000 && c < 6000:
+		return CategoryIntegration
+	case c >= 6000 && c < 7000:
+		return CategoryBusiness
+	case c >= 7000 && c < 7500:
+		return CategoryInfo
+	case c >= 7500 && c < 8000:
+		return CategoryWarning
+	default:
+		return CategoryUnknown
+	}
+}
+
+// HTTPStatus returns the HTTP status the error code maps to. Codes without their own mapping
+// get the status of their category (400 for validation, 500 for system, ...), info and
+// warning codes 200, and unknown codes 0.
+func (c Errorcode) HTTPStatus() int {
+	if status, ok := httpStatuses[c]; ok {
+		return status
+	}
+
+	switch c.Category() {
+	case CategoryValidation:
+		return 400
+	case CategoryAuthentication:
+		return 401
+	case CategoryResource:
+		return 404
+	case CategorySystem:
+		return 500
+	case CategoryIntegration:
+		return 502
+	case CategoryBusiness:
+		return 422
+	case CategoryInfo, CategoryWarning:
+		return 200
+	default:
+		return 0
+	}
+}
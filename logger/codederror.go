@@ -0,0 +1,102 @@
+package logger
+
+import "fmt"
+
+// CodedError is returned by Error and Critical under WithCodedErrors. It carries the code
+// and client messages of the log, so handlers can return it and an upstream middleware can
+// render the response from it.
+type CodedError struct {
+	Errorcode       Errorcode // Error code of the log.
+	ClientMessageUz string    // Client message in Uzbek.
+	ClientMessageRu string    // Client message in Russian.
+	Status          int       // HTTP status of the log, or the one the code maps to.
+	Err             error     // Error behind the log (LogRequest.Err); may be nil.
+	LogErr          error     // Error publishing the log; nil if it was published.
+}
+
+// Error describes the coded error.
+func (e *CodedError) Error() string {
+	message := e.ClientMessageRu
+	if message == "" {
+		message = e.ClientMessageUz
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("code %d: %s: %s", e.Errorcode, message, e.Err)
+	}
+	return fmt.Sprintf("code %d: %s", e.Errorcode, message)
+}
+
+// Unwrap returns the error behind the log and the publishing error, if any.
+func (e *CodedError) Unwrap() []error {
+	var errs []error
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	if e.LogErr != nil {
+		errs = append(errs, e.LogErr)
+	}
+	return errs
+}
+
+// Code returns the error code, so coded errors are recognized when logged again (see LogRequest.Err).
+func (e *CodedError) Code() Errorcode {
+	return e.Errorcode
+}
+
+// Response returns the error body of the error in the language. The client messages of the
+// log take precedence for Uzbek and Russian; other languages come from the translator (see ErrorResponse).
+//
+// Usage:
+//
+//	var coded *logger.CodedError
+//	if errors.As(err, &coded) {
+//		resp := coded.Response(r.Header.Get("Accept-Language"))
+//		w.WriteHeader(resp.Status)
+//		_ = json.NewEncoder(w).Encode(resp)
+//	}
+func (e *CodedError) Response(lang string) APIError {
+	resp := ErrorResponse(e.Errorcode, lang)
+	resp.Status = e.Status
+
+	local := ClientMessage{Uz: e.ClientMessageUz, Ru: e.ClientMessageRu}
+	switch language(lang) {
+	case "", LangUz, LangRu:
+		if message := local.In(lang); message != "" {
+			resp.Message = message
+		}
+	}
+	return resp
+}
+
+// WithCodedErrors makes Error and Critical (and their Context variants) return a *CodedError
+// for every log, so handlers can `return log.Error(...)`. Publishing and validation failures
+// are kept in CodedError.LogErr; errors.Is still matches them.
+func WithCodedErrors() Option {
+	return func(l *logger) {
+		l.codedErrors = true
+	}
+}
+
+// codedError builds the error returned for a log under WithCodedErrors.
+func (l *logger) codedError(log LogRequest, logErr error) error {
+	code := log.Errorcode
+	if c, ok := errorCode(log.Err); ok && code == 0 {
+		code = c
+	}
+	status := log.StatusCode
+	if status == 0 {
+		status = code.HTTPStatus()
+	}
+	if status == 0 {
+		status = 500
+	}
+
+	return &CodedError{
+		Errorcode:       code,
+		ClientMessageUz: log.ClientMessageUz,
+		ClientMessageRu: log.ClientMessageRu,
+		Status:          status,
+		Err:             log.Err,
+		LogErr:          logErr,
+	}
+}
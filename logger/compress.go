@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"sync"
+)
+
+// PayloadEncodingGzip flags a field holding the base64 encoding of its gzip-compressed value.
+const PayloadEncodingGzip = "gzip+base64"
+
+// gzipWriterPool recycles the gzip writers, which are expensive to allocate.
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(nil) },
+}
+
+// WithPayloadCompression gzips request payloads and response data longer than threshold bytes
+// and publishes them base64 encoded, flagged with `request_payload_encoding`/`response_data_encoding`
+// set to "gzip+base64". The other fields stay readable; consumers.Decode decompresses the fields.
+// A threshold of zero or less defaults to 4 KiB.
+func WithPayloadCompression(threshold int) Option {
+	return func(l *logger) {
+		if threshold <= 0 {
+			threshold = 4 << 10
+		}
+		l.compressThreshold = threshold
+	}
+}
+
+// compressPayloads compresses the large payloads of the log.
+// Payloads that do not shrink or are already encoded (see sanitizePayloads) are left as they are.
+func (l *logger) compressPayloads(log *LogRecord) {
+	if len(log.RequestPayload) > l.compressThreshold && log.RequestPayloadEncoding == "" {
+		if compressed, ok := compressPayload(log.RequestPayload); ok {
+			log.RequestPayload, log.RequestPayloadEncoding = compressed, PayloadEncodingGzip
+		}
+	}
+	if len(log.ResponseData) > l.compressThreshold && log.ResponseDataEncoding == "" {
+		if compressed, ok := compressPayload(log.ResponseData); ok {
+			log.ResponseData, log.ResponseDataEncoding = compressed, PayloadEncodingGzip
+		}
+	}
+}
+
+// compressPayload returns the base64 encoding of the gzip-compressed value, reporting false
+// when it would not be shorter than the value.
+func compressPayload(value string) (string, bool) {
+	var buf bytes.Buffer
+	zw := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(zw)
+	zw.Reset(&buf)
+
+	if _, err := zw.Write([]byte(value)); err != nil {
+		return "", false
+	}
+	if err := zw.Close(); err != nil {
+		return "", false
+	}
+	if base64.StdEncoding.EncodedLen(buf.Len()) >= len(value) {
+		return "", false
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), true
+}
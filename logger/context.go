@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/netip"
+)
+
+// metadataContextKey is the context key of the metadata set with InjectMetadata.
+type metadataContextKey struct{}
+
+// ContextMetadata is request-scoped metadata carried by a context. Middlewares inject it once
+// and the context-aware log methods (InfoContext, ...) add it to every log of the request.
+type ContextMetadata struct {
+	RequestID      string // ID of the request, e.g. from the X-Request-ID header.
+	UserID         string // ID of the authenticated user.
+	SessionID      string // ID of the user's session.
+	MerchantApiKey string // API key of the calling merchant.
+	ApiEndpoint    string // API endpoint of the request.
+	Method         string // HTTP method of the request.
+	ClientIP       string // IP address of the client.
+	UserAgent      string // User-Agent of the client.
+}
+
+// InjectMetadata returns a copy of the context carrying the metadata. Empty fields keep
+// the values already carried by the context, so middlewares can add to each other's metadata.
+//
+// Usage:
+//
+//	ctx := logger.InjectMetadata(r.Context(), logger.ContextMetadata{UserID: claims.UserID})
+//	next.ServeHTTP(w, r.WithContext(ctx))
+func InjectMetadata(ctx context.Context, metadata ContextMetadata) context.Context {
+	current := MetadataFromContext(ctx)
+	fill(&metadata.RequestID, current.RequestID)
+	fill(&metadata.UserID, current.UserID)
+	fill(&metadata.SessionID, current.SessionID)
+	fill(&metadata.MerchantApiKey, current.MerchantApiKey)
+	fill(&metadata.ApiEndpoint, current.ApiEndpoint)
+	fill(&metadata.Method, current.Method)
+	fill(&metadata.ClientIP, current.ClientIP)
+	fill(&metadata.UserAgent, current.UserAgent)
+	return context.WithValue(ctx, metadataContextKey{}, metadata)
+}
+
+// MetadataFromContext returns the metadata carried by the context, empty if none.
+func MetadataFromContext(ctx context.Context) ContextMetadata {
+	metadata, _ := ctx.Value(metadataContextKey{}).(ContextMetadata)
+	return metadata
+}
+
+// fill sets the empty field to the value.
+func fill(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// ContextWithUser returns a copy of the context carrying the user and session of the request,
+// see InjectMetadata.
+func ContextWithUser(ctx context.Context, userID, sessionID string) context.Context {
+	return InjectMetadata(ctx, ContextMetadata{UserID: userID, SessionID: sessionID})
+}
+
+// UserFromContext returns the user and session carried by the context, empty if none.
+func UserFromContext(ctx context.Context) (userID, sessionID string) {
+	metadata := MetadataFromContext(ctx)
+	return metadata.UserID, metadata.SessionID
+}
+
+// WithContext returns a copy of the log with the metadata carried by the context filled in
+// where the log leaves it empty.
+func (log LogRequest) WithContext(ctx context.Context) LogRequest {
+	metadata := MetadataFromContext(ctx)
+	fill(&log.RequestID, metadata.RequestID)
+	fill(&log.UserID, metadata.UserID)
+	fill(&log.SessionID, metadata.SessionID)
+	fill(&log.MerchantApiKey, metadata.MerchantApiKey)
+	fill(&log.ApiEndpoint, metadata.ApiEndpoint)
+	fill(&log.Method, metadata.Method)
+	fill(&log.ClientIP, metadata.ClientIP)
+	fill(&log.UserAgent, metadata.UserAgent)
+	return log
+}
+
+// MiddlewareOption configures Middleware, UnaryServerInterceptor and StreamServerInterceptor.
+type MiddlewareOption func(*middlewareConfig)
+
+// WithTrustedProxies sets the networks of the proxies in front of the service. The forwarded
+// client addresses are then only read from requests of these proxies, and the client IP is the
+// last forwarded address that is not one of them, which clients cannot forge. Without trusted
+// proxies, the first forwarded address is used (see LogRequest.WithRequest).
+//
+// Usage:
+//
+//	handler := logger.Middleware(mux, logger.WithTrustedProxies(netip.MustParsePrefix("10.0.0.0/8")))
+func WithTrustedProxies(prefixes ...netip.Prefix) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.trustedProxies = append(c.trustedProxies, prefixes...)
+	}
+}
+
+// middlewareConfig holds the settings of the middlewares.
+type middlewareConfig struct {
+	trustedProxies []netip.Prefix // Networks of the proxies whose forwarded addresses are trusted.
+}
+
+// newMiddlewareConfig applies the options.
+func newMiddlewareConfig(opts []MiddlewareOption) middlewareConfig {
+	var config middlewareConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// Middleware injects the metadata of every HTTP request into its context: the request ID
+// (from X-Request-ID, generated when missing and echoed in the response), the endpoint,
+// the method, the client IP (see WithTrustedProxies) and the User-Agent.
+//
+// Usage:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/orders", createOrder) // logs with log.ErrorContext(r.Context(), ...)
+//	err := http.ListenAndServe(":8080", logger.Middleware(mux))
+func Middleware(next http.Handler, opts ...MiddlewareOption) http.Handler {
+	config := newMiddlewareConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get("X-Request-ID")
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set("X-Request-ID", requestID)
+
+		fields := LogRequest{}.WithRequest(r)
+		ctx := InjectMetadata(r.Context(), ContextMetadata{
+			RequestID:   requestID,
+			ApiEndpoint: fields.ApiEndpoint,
+			Method:      fields.Method,
+			ClientIP:    clientAddress(r.RemoteAddr, r.Header.Values("X-Forwarded-For"), r.Header.Get("X-Real-IP"), config.trustedProxies),
+			UserAgent:   fields.UserAgent,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// newRequestID returns a random request ID.
+func newRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
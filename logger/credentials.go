@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+)
+
+// Credentials are the user and password of a broker connection.
+type Credentials struct {
+	Username string `json:"username"` // Broker user.
+	Password string `json:"password"` // Password of the user.
+}
+
+// CredentialProvider fetches the broker credentials. It is called on every (re)connection, so
+// a provider backed by Vault or AWS Secrets Manager hands out rotated secrets without a
+// redeploy. A provider wrapping a secret manager client is a plain function:
+//
+//	provider := func(ctx context.Context) (logger.Credentials, error) {
+//		secret, err := vault.KVv2("secret").Get(ctx, "rabbitmq/logger")
+//		if err != nil {
+//			return logger.Credentials{}, err
+//		}
+//		return logger.Credentials{
+//			Username: secret.Data["username"].(string),
+//			Password: secret.Data["password"].(string),
+//		}, nil
+//	}
+type CredentialProvider func(ctx context.Context) (Credentials, error)
+
+// FileCredentials returns a provider reading the credentials from a JSON file with "username"
+// and "password" keys, as rendered by the Vault agent or mounted by the Secrets Store CSI
+// driver. The file is read on every call, so rotations are picked up on the next connection.
+func FileCredentials(path string) CredentialProvider {
+	return func(ctx context.Context) (Credentials, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("failed to read credentials: %w", err)
+		}
+		var creds Credentials
+		if err := json.Unmarshal(data, &creds); err != nil {
+			return Credentials{}, fmt.Errorf("failed to decode credentials: %w", err)
+		}
+		return creds, nil
+	}
+}
+
+// URLWithCredentials returns the broker URL with its user and password replaced by the ones of
+// the provider. A nil provider returns the URL unchanged.
+//
+// Usage:
+//
+//	brokerURL, err := logger.URLWithCredentials(ctx, "amqp://rabbitmq:5672/", logger.FileCredentials("/vault/secrets/rabbitmq.json"))
+//	if err != nil {
+//		return err
+//	}
+//	rabbitMQ, err := rabbitmq.NewRabbitMQ(brokerURL, nil)
+func URLWithCredentials(ctx context.Context, rawURL string, provider CredentialProvider) (string, error) {
+	if provider == nil {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse broker url: %w", err)
+	}
+	creds, err := provider(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch broker credentials: %w", err)
+	}
+	u.User = url.UserPassword(creds.Username, creds.Password)
+	return u.String(), nil
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// DebugOption configures the handler returned by DebugHandler.
+type DebugOption func(*debugHandler)
+
+// WithLevelControl lets the debug handler change the minimal level of the logger at runtime.
+func WithLevelControl() DebugOption {
+	return func(h *debugHandler) {
+		h.levelControl = true
+	}
+}
+
+// debugHandler serves the health and diagnostics of a logger, see DebugHandler.
+type debugHandler struct {
+	logger       Logger // Logger reported on.
+	levelControl bool   // Whether PUT .../level is accepted.
+}
+
+// DebugHandler returns an HTTP handler serving the health and diagnostics of the logger as JSON,
+// to mount under an internal admin route. Paths are matched by their last segment, so the
+// handler works under any prefix:
+//
+//   - GET .../health answers 200 while deliveries succeed and 503 after a failed one;
+//   - GET .../level returns the minimal level; with WithLevelControl, PUT .../level with
+//     {"level": "debug"} changes it;
+//   - GET on any other path returns the Diagnostics.
+//
+// Usage:
+//
+//	mux.Handle("/internal/logger/", logger.DebugHandler(log, logger.WithLevelControl()))
+func DebugHandler(l Logger, opts ...DebugOption) http.Handler {
+	h := &debugHandler{logger: l}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP routes the request by the last segment of its path.
+func (h *debugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch path := strings.TrimSuffix(r.URL.Path, "/"); {
+	case strings.HasSuffix(path, "/health") || path == "health":
+		h.health(w, r)
+	case strings.HasSuffix(path, "/level") || path == "level":
+		h.level(w, r)
+	default:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		writeDebugJSON(w, http.StatusOK, h.logger.Diagnostics())
+	}
+}
+
+// health reports whether the logger delivers its logs.
+func (h *debugHandler) health(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	d := h.logger.Diagnostics()
+	status, code := "ok", http.StatusOK
+	if !d.Connected {
+		status, code = "failing", http.StatusServiceUnavailable
+	}
+	writeDebugJSON(w, code, map[string]any{
+		"status":     status,
+		"last_error": d.LastError,
+		"buffered":   d.Buffered,
+		"dropped":    d.Dropped,
+	})
+}
+
+// level returns or changes the minimal level.
+func (h *debugHandler) level(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet:
+	case r.Method == http.MethodPut && h.levelControl:
+		var body struct {
+			Level Level `json:"level"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeDebugJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		h.logger.SetMinLevel(body.Level)
+	case h.levelControl:
+		methodNotAllowed(w, http.MethodGet+", "+http.MethodPut)
+		return
+	default:
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	writeDebugJSON(w, http.StatusOK, map[string]string{"level": h.logger.MinLevel().String()})
+}
+
+// methodNotAllowed answers 405 with the allowed methods.
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	writeDebugJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+}
+
+// writeDebugJSON writes the value as a JSON response.
+func writeDebugJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
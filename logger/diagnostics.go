@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Diagnostics is a report of the state of a logger, returned by Logger.Diagnostics to debug
+// missing logs: whether the broker accepts messages, where they go and what was dropped.
+type Diagnostics struct {
+	ProducerID       string `json:"producer_id"`                  // Random ID of the logger instance.
+	Queue            string `json:"queue"`                        // Queue or topic of the logs.
+	OrderQueue       string `json:"order_queue,omitempty"`        // Queue of order notifications.
+	BitrixOrderQueue string `json:"bitrix_order_queue,omitempty"` // Queue of Bitrix orders.
+
+	// Connected is false from a failed delivery to the next successful one. The transports do
+	// not expose their connection, so it reflects the outcome of the last delivery.
+	Connected bool `json:"connected"`
+
+	Published uint64 `json:"published"` // Messages accepted by the transport.
+	Failed    uint64 `json:"failed"`    // Messages the transport failed to deliver.
+	Buffered  int    `json:"buffered"`  // Logs waiting in the async buffer.
+	Dropped   uint64 `json:"dropped"`   // Logs dropped because the async buffer was full.
+	Sequence  uint64 `json:"sequence"`  // Sequence number of the last accepted log.
+
+	LastPublishAt *time.Time `json:"last_publish_at,omitempty"` // Time of the last successful delivery.
+	LastError     string     `json:"last_error,omitempty"`      // Error of the last failed delivery.
+	LastErrorAt   *time.Time `json:"last_error_at,omitempty"`   // Time of the last failed delivery.
+
+	Config DiagnosticsConfig `json:"config"` // Settings of the logger.
+}
+
+// DiagnosticsConfig is the snapshot of the logger settings included in Diagnostics.
+type DiagnosticsConfig struct {
+	FunctionName      string          `json:"function_name"`               // Function name of the logs.
+	ApiEndpoint       string          `json:"api_endpoint"`                // Default API endpoint of the logs.
+	Transport         string          `json:"transport"`                   // Type of the transport.
+	Encoding          string          `json:"encoding"`                    // Wire format of the logs.
+	MinLevel          string          `json:"min_level"`                   // Minimal level of the published logs.
+	Async             bool            `json:"async"`                       // Whether logs are published in the background.
+	AsyncBufferSize   int             `json:"async_buffer_size,omitempty"` // Capacity of the async buffer.
+	AsyncPublishers   int             `json:"async_publishers,omitempty"`  // Number of publishing goroutines.
+	RawPayload        bool            `json:"raw_payload"`                 // See WithRawPayload.
+	CompressThreshold int             `json:"compress_threshold"`          // See WithPayloadCompression.
+	Offload           bool            `json:"offload"`                     // Whether large payloads are offloaded.
+	PublishInvalid    bool            `json:"publish_invalid"`             // See ValidationPolicy.PublishInvalid.
+	Environment       string          `json:"environment,omitempty"`       // Environment of the producer.
+	Sinks             map[string]bool `json:"sinks,omitempty"`             // Attached sinks and whether they are enabled.
+}
+
+// Stats holds the counters of a logger since it was created, returned by Logger.Stats. In
+// async mode the level methods return before the logs are published, so the counters are the
+// way to know whether logs actually left the process.
+type Stats struct {
+	Published         uint64 `json:"published"`           // Messages accepted by the transport, logs and orders alike.
+	Failed            uint64 `json:"failed"`              // Messages the transport failed to deliver, after the retries.
+	DroppedBySampling uint64 `json:"dropped_by_sampling"` // Logs discarded by a sampling policy before being buffered; zero until the logger samples logs.
+	DroppedByBuffer   uint64 `json:"dropped_by_buffer"`   // Logs dropped because the async buffer or its memory budget was full.
+	Retried           uint64 `json:"retried"`             // Deliveries attempted again after a failure, see WithPublishRetry.
+}
+
+// publishStats counts the deliveries of a logger.
+type publishStats struct {
+	published   atomic.Uint64                  // Messages accepted by the transport.
+	failed      atomic.Uint64                  // Messages the transport failed to deliver.
+	retried     atomic.Uint64                  // Deliveries attempted again after a failure.
+	lastSuccess atomic.Int64                   // Unix time in nanoseconds of the last successful delivery.
+	lastFailure atomic.Pointer[publishFailure] // Last failed delivery.
+}
+
+// publishFailure is a failed delivery.
+type publishFailure struct {
+	err error     // Error returned by the transport.
+	at  time.Time // Time of the delivery.
+}
+
+// record counts the delivery of n messages.
+func (s *publishStats) record(err error, n int) {
+	if err != nil {
+		s.failed.Add(uint64(n))
+		s.lastFailure.Store(&publishFailure{err: err, at: time.Now()})
+		return
+	}
+	s.published.Add(uint64(n))
+	s.lastSuccess.Store(time.Now().UnixNano())
+}
+
+// Stats returns the counters of the logger.
+func (l *logger) Stats() Stats {
+	return Stats{
+		Published:       l.stats.published.Load(),
+		Failed:          l.stats.failed.Load(),
+		DroppedByBuffer: l.Dropped(),
+		Retried:         l.stats.retried.Load(),
+	}
+}
+
+// Diagnostics returns a report of the state of the logger.
+func (l *logger) Diagnostics() Diagnostics {
+	d := Diagnostics{
+		ProducerID:       l.producerID,
+		Queue:            l.queue,
+		OrderQueue:       l.orderQueue,
+		BitrixOrderQueue: l.bitrixOrderQueue,
+		Connected:        true,
+		Published:        l.stats.published.Load(),
+		Failed:           l.stats.failed.Load(),
+		Dropped:          l.Dropped(),
+		Sequence:         l.sequence.Load(),
+		Config: DiagnosticsConfig{
+			FunctionName:      l.functionName,
+			ApiEndpoint:       l.apiEndpoint,
+			Transport:         fmt.Sprintf("%T", l.transport),
+			Encoding:          l.encoding.String(),
+			MinLevel:          l.MinLevel().String(),
+			Async:             l.async != nil,
+			RawPayload:        l.rawPayload,
+			CompressThreshold: l.compressThreshold,
+			Offload:           l.offload != nil,
+			PublishInvalid:    l.validation.publishInvalid,
+			Environment:       l.metadata.Environment,
+		},
+	}
+
+	if l.async != nil {
+		for _, s := range l.async.shards {
+			d.Buffered += s.ring.len()
+		}
+		d.Config.AsyncBufferSize = l.async.config.BufferSize
+		d.Config.AsyncPublishers = l.async.config.Publishers
+	}
+	if len(l.sinks) > 0 {
+		d.Config.Sinks = make(map[string]bool, len(l.sinks))
+		for _, sink := range l.sinks {
+			d.Config.Sinks[sink.Name()] = l.SinkEnabled(sink.Name())
+		}
+	}
+
+	var lastSuccess time.Time
+	if nanos := l.stats.lastSuccess.Load(); nanos != 0 {
+		lastSuccess = time.Unix(0, nanos)
+		d.LastPublishAt = &lastSuccess
+	}
+	if failure := l.stats.lastFailure.Load(); failure != nil {
+		d.LastError = failure.err.Error()
+		d.LastErrorAt = &failure.at
+		d.Connected = failure.at.Before(lastSuccess)
+	}
+	return d
+}
+
+// diagnosticsReport writes the diagnostics of a logger periodically, see WithDiagnosticsReport.
+type diagnosticsReport struct {
+	interval time.Duration // Interval between two reports.
+	w        io.Writer     // Destination of the reports.
+	stop     chan struct{} // Closed when the logger is closed.
+	stopOnce sync.Once     // Guards closing of stop.
+}
+
+// WithDiagnosticsReport writes the Diagnostics of the logger to w as a JSON line every interval,
+// e.g. to the service's stderr, which stays readable when the broker is the problem.
+// A nil writer defaults to os.Stderr. The report stops when the logger is closed.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", nil, nil,
+//		logger.WithDiagnosticsReport(time.Minute, nil),
+//	)
+func WithDiagnosticsReport(interval time.Duration, w io.Writer) Option {
+	return func(l *logger) {
+		if interval <= 0 {
+			l.report = nil
+			return
+		}
+		if w == nil {
+			w = os.Stderr
+		}
+		l.report = &diagnosticsReport{interval: interval, w: w, stop: make(chan struct{})}
+	}
+}
+
+// run writes the diagnostics of the logger every interval until the report is stopped.
+func (r *diagnosticsReport) run(l *logger) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	encoder := json.NewEncoder(r.w)
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			_ = encoder.Encode(l.Diagnostics())
+		}
+	}
+}
+
+// close stops the report.
+func (r *diagnosticsReport) close() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"io"
+	"sync"
+)
+
+// writerTransport is a Transport writing the messages to a local writer, see WithDryRun.
+type writerTransport struct {
+	mu sync.Mutex // Serializes writes of concurrent publishers.
+	w  io.Writer  // Destination of the messages.
+}
+
+// NewWriterTransport returns a Transport writing every message body to w, followed by a newline,
+// instead of delivering it to a broker. With the JSON encoding the output is one log per line;
+// binary encodings are written as is.
+func NewWriterTransport(w io.Writer) Transport {
+	return &writerTransport{w: w}
+}
+
+// Declare does nothing, the writer has no destinations.
+func (t *writerTransport) Declare(destination string) error {
+	return nil
+}
+
+// Publish writes the message body and a newline.
+func (t *writerTransport) Publish(msg Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.w.Write(msg.Body); err != nil {
+		return err
+	}
+	_, err := t.w.Write([]byte{'\n'})
+	return err
+}
+
+// Close does nothing; the writer is owned by the caller.
+func (t *writerTransport) Close() error {
+	return nil
+}
+
+// WithDryRun writes the logs to w instead of publishing them, for load tests and staging
+// environments that must not pollute the production queues. Logs still go through validation,
+// enrichment, encoding and the attached sinks; order messages are written to w as well.
+// No queue is declared on the transport given to the constructor; to run without any broker
+// connection, pass NewWriterTransport(w) to NewLoggerWithTransport instead.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", nil, nil,
+//		logger.WithDryRun(os.Stdout),
+//	)
+func WithDryRun(w io.Writer) Option {
+	return func(l *logger) {
+		l.transport = NewWriterTransport(w)
+	}
+}
@@ -0,0 +1,176 @@
+package logger
+
+import (
+	"fmt"
+	"net/smtp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmailConfig holds the settings of the SMTP digest sink.
+type EmailConfig struct {
+	Host       string        `yaml:"host"`        // SMTP server host.
+	Port       int           `yaml:"port"`        // SMTP server port. Defaults to 587.
+	Username   string        `yaml:"username"`    // SMTP username; empty disables authentication.
+	Password   string        `yaml:"password"`    // SMTP password.
+	From       string        `yaml:"from"`        // Sender address.
+	To         []string      `yaml:"to"`          // Recipient addresses.
+	Service    string        `yaml:"service"`     // Service name shown in the digest subject.
+	MinLevel   *Level        `yaml:"min_level"`   // Minimal level included in the digest. Defaults to LevelError when nil.
+	Interval   time.Duration `yaml:"interval"`    // Interval between digests. Defaults to 1 hour.
+	MaxEntries int           `yaml:"max_entries"` // Maximum number of logs listed in one digest; extra logs are only counted. Defaults to 200.
+}
+
+// emailSink collects logs and sends them as periodic digest emails.
+type emailSink struct {
+	config   EmailConfig    // Sink settings with defaults applied.
+	minLevel Level          // Minimal level included in the digest.
+	mu       sync.Mutex     // Protects entries and counts.
+	entries  []LogRecord    // Logs listed in the next digest.
+	counts   map[string]int // Number of logs per level and code in the next digest.
+	total    int            // Number of logs in the next digest.
+	stop     chan struct{}  // Closed to stop the digest loop.
+	done     chan struct{}  // Closed when the digest loop has exited.
+	once     sync.Once      // Guards closing of stop.
+}
+
+// NewEmailDigestSink initializes and returns a sink sending periodic digests of error and critical logs by email.
+// A digest is only sent when at least one log was collected during the interval.
+func NewEmailDigestSink(config EmailConfig) Sink {
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	minLevel := LevelError
+	if config.MinLevel != nil {
+		minLevel = *config.MinLevel
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = 200
+	}
+
+	s := &emailSink{
+		config:   config,
+		minLevel: minLevel,
+		counts:   make(map[string]int),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Name returns the sink name.
+func (s *emailSink) Name() string {
+	return "email"
+}
+
+// Write adds the log to the next digest if its level is high enough.
+func (s *emailSink) Write(log LogRecord) error {
+	level, err := ParseLevel(log.ErrorLevel)
+	if err != nil || level < s.minLevel {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.total++
+	s.counts[fmt.Sprintf("%s %d", log.ErrorLevel, log.Errorcode)]++
+	if len(s.entries) < s.config.MaxEntries {
+		s.entries = append(s.entries, log)
+	}
+	return nil
+}
+
+// Close sends the pending digest and stops the sink.
+func (s *emailSink) Close() error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+	return nil
+}
+
+// run sends a digest every interval until the sink is closed.
+func (s *emailSink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.stop:
+			s.flush()
+			return
+		}
+	}
+}
+
+// flush sends the collected logs as one digest and resets the collection.
+// Delivery errors are dropped since there is nowhere to report them.
+func (s *emailSink) flush() {
+	s.mu.Lock()
+	entries, counts, total := s.entries, s.counts, s.total
+	s.entries, s.counts, s.total = nil, make(map[string]int), 0
+	s.mu.Unlock()
+
+	if total == 0 {
+		return
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	_ = smtp.SendMail(addr, auth, s.config.From, s.config.To, s.digest(entries, counts, total))
+}
+
+// digest renders the email message, headers included.
+func (s *emailSink) digest(entries []LogRecord, counts map[string]int, total int) []byte {
+	var b strings.Builder
+
+	subject := fmt.Sprintf("[%s] %d error logs in the last %s", s.config.Service, total, s.config.Interval)
+	fmt.Fprintf(&b, "From: %s\r\n", s.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(s.config.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+
+	b.WriteString("Summary (level, code: count)\r\n")
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "  %s: %d\r\n", key, counts[key])
+	}
+
+	b.WriteString("\r\nLogs\r\n")
+	for _, log := range entries {
+		fmt.Fprintf(&b, "\r\n%s  %s  %d  %s %s\r\n", log.Timestamp.Format(time.RFC3339), strings.ToUpper(log.ErrorLevel), log.Errorcode, log.Method, log.ApiEndpoint)
+		if log.FunctionName != "" {
+			fmt.Fprintf(&b, "  Function: %s\r\n", log.FunctionName)
+		}
+		fmt.Fprintf(&b, "  Message: %s\r\n", log.ClientMessageUz)
+		if log.ErrorMessage != "" {
+			fmt.Fprintf(&b, "  Error: %s\r\n", truncate(log.ErrorMessage, 500))
+		}
+	}
+	if total > len(entries) {
+		fmt.Fprintf(&b, "\r\n... and %d more logs not listed.\r\n", total-len(entries))
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,276 @@
+package logger
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// appendLogRequest appends the JSON encoding of the log to dst without reflection.
+// The output is byte for byte what json.Marshal produces for LogRecord, so consumers cannot
+// tell the two apart; keep it in sync with the struct fields and tags.
+// It reports false for logs json.Marshal would reject (timestamps outside years 0-9999,
+// unencodable extra metadata) and for the rare logs with offloaded payloads, which are
+// left to encoding/json.
+func appendLogRequest(dst []byte, log *LogRecord) ([]byte, bool) {
+	if y := log.Timestamp.Year(); y < 0 || y > 9999 {
+		return dst, false
+	}
+	if log.ExpiresAt != nil {
+		if y := log.ExpiresAt.Year(); y < 0 || y > 9999 {
+			return dst, false
+		}
+	}
+	if log.RequestPayloadRef != nil || log.ResponseDataRef != nil {
+		return dst, false
+	}
+
+	dst = append(dst, `{"timestamp":"`...)
+	dst = log.Timestamp.AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, `","error_level":`...)
+	dst = appendJSONString(dst, log.ErrorLevel)
+	dst = append(dst, `,"error_code":`...)
+	dst = strconv.AppendInt(dst, int64(log.Errorcode), 10)
+	dst = append(dst, `,"client_message_uz":`...)
+	dst = appendJSONString(dst, log.ClientMessageUz)
+	dst = append(dst, `,"client_message_ru":`...)
+	dst = appendJSONString(dst, log.ClientMessageRu)
+	dst = append(dst, `,"error_message":`...)
+	dst = appendJSONString(dst, log.ErrorMessage)
+	if log.DetailsUz != "" {
+		dst = append(dst, `,"details_uz":`...)
+		dst = appendJSONString(dst, log.DetailsUz)
+	}
+	if log.DetailsRu != "" {
+		dst = append(dst, `,"details_ru":`...)
+		dst = appendJSONString(dst, log.DetailsRu)
+	}
+	dst = append(dst, `,"api_endpoint":`...)
+	if s := log.static; s != nil && log.ApiEndpoint == s.apiEndpoint {
+		dst = append(dst, s.apiEndpointJSON...)
+	} else {
+		dst = appendJSONString(dst, log.ApiEndpoint)
+	}
+	dst = append(dst, `,"method":`...)
+	dst = appendJSONString(dst, log.Method)
+	dst = append(dst, `,"function_name":`...)
+	if s := log.static; s != nil && log.FunctionName == s.functionName {
+		dst = append(dst, s.functionNameJSON...)
+	} else {
+		dst = appendJSONString(dst, log.FunctionName)
+	}
+	dst = append(dst, `,"status_code":`...)
+	dst = strconv.AppendInt(dst, int64(log.StatusCode), 10)
+	dst = append(dst, `,"request_payload":`...)
+	dst = appendJSONString(dst, log.RequestPayload)
+	dst = append(dst, `,"event_type":`...)
+	dst = appendJSONString(dst, log.EventType)
+	if log.ResponseData != "" {
+		dst = append(dst, `,"response_data":`...)
+		dst = appendJSONString(dst, log.ResponseData)
+	}
+	if log.MerchantApiKey != "" {
+		dst = append(dst, `,"merchant_api_key":`...)
+		dst = appendJSONString(dst, log.MerchantApiKey)
+	}
+	if log.DurationMs != 0 {
+		dst = append(dst, `,"duration_ms":`...)
+		dst = strconv.AppendInt(dst, log.DurationMs, 10)
+	}
+	if log.RequestPayloadEncoding != "" {
+		dst = append(dst, `,"request_payload_encoding":`...)
+		dst = appendJSONString(dst, log.RequestPayloadEncoding)
+	}
+	if log.ResponseDataEncoding != "" {
+		dst = append(dst, `,"response_data_encoding":`...)
+		dst = appendJSONString(dst, log.ResponseDataEncoding)
+	}
+	if log.ValidationFailed {
+		dst = append(dst, `,"validation_failed":true`...)
+	}
+	if log.ValidationError != "" {
+		dst = append(dst, `,"validation_error":`...)
+		dst = appendJSONString(dst, log.ValidationError)
+	}
+	dst = append(dst, `,"producer_id":`...)
+	dst = appendJSONString(dst, log.ProducerID)
+	dst = append(dst, `,"sequence":`...)
+	dst = strconv.AppendUint(dst, log.Sequence, 10)
+	dst = append(dst, `,"schema_version":`...)
+	dst = strconv.AppendInt(dst, int64(log.SchemaVersion), 10)
+	if log.Host != "" {
+		dst = append(dst, `,"host":`...)
+		dst = appendJSONString(dst, log.Host)
+	}
+	if log.Pod != "" {
+		dst = append(dst, `,"pod":`...)
+		dst = appendJSONString(dst, log.Pod)
+	}
+	if log.Service != "" {
+		dst = append(dst, `,"service":`...)
+		dst = appendJSONString(dst, log.Service)
+	}
+	if log.InstanceID != "" {
+		dst = append(dst, `,"instance_id":`...)
+		dst = appendJSONString(dst, log.InstanceID)
+	}
+	if log.Environment != "" {
+		dst = append(dst, `,"environment":`...)
+		dst = appendJSONString(dst, log.Environment)
+	}
+	if log.AppVersion != "" {
+		dst = append(dst, `,"app_version":`...)
+		dst = appendJSONString(dst, log.AppVersion)
+	}
+	if log.GitCommit != "" {
+		dst = append(dst, `,"git_commit":`...)
+		dst = appendJSONString(dst, log.GitCommit)
+	}
+	if log.UserID != "" {
+		dst = append(dst, `,"user_id":`...)
+		dst = appendJSONString(dst, log.UserID)
+	}
+	if log.SessionID != "" {
+		dst = append(dst, `,"session_id":`...)
+		dst = appendJSONString(dst, log.SessionID)
+	}
+	if log.ClientIP != "" {
+		dst = append(dst, `,"client_ip":`...)
+		dst = appendJSONString(dst, log.ClientIP)
+	}
+	if log.UserAgent != "" {
+		dst = append(dst, `,"user_agent":`...)
+		dst = appendJSONString(dst, log.UserAgent)
+	}
+	if log.RequestID != "" {
+		dst = append(dst, `,"request_id":`...)
+		dst = appendJSONString(dst, log.RequestID)
+	}
+	if len(log.Errors) > 0 {
+		dst = append(dst, `,"errors":[`...)
+		for i, detail := range log.Errors {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, '{')
+			if detail.Code != 0 {
+				dst = append(dst, `"code":`...)
+				dst = strconv.AppendInt(dst, int64(detail.Code), 10)
+				dst = append(dst, ',')
+			}
+			dst = append(dst, `"message":`...)
+			dst = appendJSONString(dst, detail.Message)
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ']')
+	}
+	if len(log.Extra) > 0 {
+		extra, err := json.Marshal(log.Extra)
+		if err != nil {
+			// encoding/json reports the error.
+			return dst, false
+		}
+		dst = append(dst, `,"extra":`...)
+		dst = append(dst, extra...)
+	}
+	if log.ExpiresAt != nil {
+		dst = append(dst, `,"expires_at":"`...)
+		dst = log.ExpiresAt.AppendFormat(dst, time.RFC3339Nano)
+		dst = append(dst, '"')
+	}
+	if log.MessageID != "" {
+		dst = append(dst, `,"message_id":`...)
+		dst = appendJSONString(dst, log.MessageID)
+	}
+	if len(log.Attachments) > 0 {
+		attachments, err := json.Marshal(log.Attachments)
+		if err != nil {
+			return dst, false
+		}
+		dst = append(dst, `,"attachments":`...)
+		dst = append(dst, attachments...)
+	}
+	if log.GroupID != "" {
+		dst = append(dst, `,"group_id":`...)
+		dst = appendJSONString(dst, log.GroupID)
+	}
+	return append(dst, '}'), true
+}
+
+// staticSegments holds the fields that are constant for a logger, escaped and quoted once
+// at construction instead of on every log. A field is only spliced in while the log still
+// carries the constant value, so per-log overrides are encoded as usual.
+type staticSegments struct {
+	functionName     string // Function name of the logger.
+	functionNameJSON []byte // Encoded function name.
+	apiEndpoint      string // Default API endpoint of the logger.
+	apiEndpointJSON  []byte // Encoded default API endpoint.
+}
+
+// newStaticSegments encodes the constant fields of a logger.
+func newStaticSegments(functionName, apiEndpoint string) *staticSegments {
+	return &staticSegments{
+		functionName:     functionName,
+		functionNameJSON: appendJSONString(nil, functionName),
+		apiEndpoint:      apiEndpoint,
+		apiEndpointJSON:  appendJSONString(nil, apiEndpoint),
+	}
+}
+
+// hexDigits are used to escape control characters.
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as a quoted JSON string with the escaping of encoding/json:
+// HTML characters and U+2028/U+2029 are escaped and invalid UTF-8 is replaced with U+FFFD.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAppendJSONStringMatchesMarshal(t *testing.T) {
+	inputs := []string{
+		"",
+		"plain text",
+		"a\bb\fc",
+		`quote " and backslash \`,
+		"<script>&amp;</script>",
+		"line separator paragraph",
+		"invalid \xff\xfe utf-8",
+		"truncated \xe2\x82",
+		"Ошибка базы данных, ma'lumot topilmadi",
+		"\x7f delete",
+	}
+	for c := 0; c < 0x20; c++ {
+		inputs = append(inputs, "x"+string(rune(c))+"y")
+	}
+
+	for _, s := range inputs {
+		want, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := appendJSONString(nil, s); !bytes.Equal(got, want) {
+			t.Errorf("appendJSONString(%q) = %s, want %s", s, got, want)
+		}
+	}
+}
+
+func TestAppendLogRequestMatchesMarshal(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	log := &LogRecord{
+		Timestamp:       time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.FixedZone("UZT", 5*3600)),
+		ErrorLevel:      "error",
+		Errorcode:       int(ErrInvalidData),
+		ClientMessageUz: "Ma'lumotlar <noto'g'ri>",
+		ClientMessageRu: "Неверный формат\bданных\f",
+		ErrorMessage:    "invalid \xff payload &  ",
+		RequestPayload:  `{"a":1}`,
+		Method:          "POST",
+		StatusCode:      400,
+		Extra:           map[string]any{"bucket": "b", "n": 1},
+		ExpiresAt:       &expiresAt,
+		MessageID:       "producer-1",
+		GroupID:         "group-1",
+		Attachments:     []Attachment{{Name: "r.pdf", ContentType: "application/pdf", Data: []byte{0, 1, 2}}},
+	}
+	log.static = newStaticSegments("Import", "/import")
+
+	want, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := appendLogRequest(nil, log)
+	if !ok {
+		t.Fatal("appendLogRequest rejected the log")
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("appendLogRequest mismatch:\n got %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrUnsupportedEncoding is returned by NewLogger for a binary encoding, which the RabbitMQ
+// client cannot publish with its content type; use NewAMQPTransport instead.
+var ErrUnsupportedEncoding = errors.New("logger: encoding not supported by the transport")
+
+// Encoding is the wire format of the published logs.
+type Encoding int
+
+// Supported encodings.
+const (
+	EncodingJSON     Encoding = iota // JSON, the default.
+	EncodingMsgPack                  // MessagePack, with the same field names as JSON.
+	EncodingProtobuf                 // Protobuf, the mybazar.logger.v1.Log message of proto/mybazar/logger/v1/log.proto.
+	EncodingAvro                     // Confluent-framed Avro records of AvroSchema, set with WithAvro.
+)
+
+// ContentType returns the MIME type of the encoding.
+func (e Encoding) ContentType() string {
+	switch e {
+	case EncodingMsgPack:
+		return "application/msgpack"
+	case EncodingProtobuf:
+		return "application/x-protobuf"
+	case EncodingAvro:
+		return "application/vnd.apache.avro+binary"
+	default:
+		return "application/json"
+	}
+}
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingMsgPack:
+		return "msgpack"
+	case EncodingProtobuf:
+		return "protobuf"
+	case EncodingAvro:
+		return "avro"
+	default:
+		return "json"
+	}
+}
+
+// WithEncoding sets the wire format of the published logs. MessagePack messages are
+// 30-40% smaller than JSON ones for typical logs, protobuf ones give consumers in other
+// languages a typed schema. consumers.Decode detects and accepts all encodings, so producers
+// can be switched one at a time. Order messages are always published as JSON.
+// WithRawPayload has no effect with binary encodings, payloads are published as strings.
+// Avro needs a schema registry and is enabled with WithAvro instead.
+// The RabbitMQ client of NewLogger publishes every message as application/json, so binary
+// encodings need the transport of NewAMQPTransport, which sets the content type.
+func WithEncoding(encoding Encoding) Option {
+	return func(l *logger) {
+		if encoding == EncodingAvro {
+			return
+		}
+		l.encoding = encoding
+	}
+}
+
+// encodeProto appends the protobuf encoding of the log to dst.
+func encodeProto(dst []byte, v any) ([]byte, error) {
+	log, ok := v.(*LogRecord)
+	if !ok {
+		return nil, fmt.Errorf("protobuf encoding is not supported for %T", v)
+	}
+
+	level, err := ParseLevel(log.ErrorLevel)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := extraJSON(log.Extra)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.MarshalOptions{}.MarshalAppend(dst, &logpb.Log{
+		Timestamp:         timestamppb.New(log.Timestamp),
+		Level:             level.proto(),
+		ErrorCode:         int32(log.Errorcode),
+		ClientMessageUz:   log.ClientMessageUz,
+		ClientMessageRu:   log.ClientMessageRu,
+		ErrorMessage:      log.ErrorMessage,
+		DetailsUz:         log.DetailsUz,
+		DetailsRu:         log.DetailsRu,
+		ApiEndpoint:       log.ApiEndpoint,
+		Method:            log.Method,
+		FunctionName:      log.FunctionName,
+		StatusCode:        int32(log.StatusCode),
+		RequestPayload:    log.RequestPayload,
+		EventType:         log.EventType,
+		ResponseData:      log.ResponseData,
+		MerchantApiKey:    log.MerchantApiKey,
+		DurationMs:        log.DurationMs,
+		RequestPayloadRef: log.RequestPayloadRef.proto(),
+		ResponseDataRef:   log.ResponseDataRef.proto(),
+
+		RequestPayloadEncoding: log.RequestPayloadEncoding,
+		ResponseDataEncoding:   log.ResponseDataEncoding,
+		ValidationFailed:       log.ValidationFailed,
+		ValidationError:        log.ValidationError,
+		ProducerId:             log.ProducerID,
+		Sequence:               log.Sequence,
+		SchemaVersion:          int32(log.SchemaVersion),
+		Host:                   log.Host,
+		Pod:                    log.Pod,
+		Service:                log.Service,
+		InstanceId:             log.InstanceID,
+		Environment:            log.Environment,
+		AppVersion:             log.AppVersion,
+		GitCommit:              log.GitCommit,
+		UserId:                 log.UserID,
+		SessionId:              log.SessionID,
+		ClientIp:               log.ClientIP,
+		UserAgent:              log.UserAgent,
+		RequestId:              log.RequestID,
+		Errors:                 errorDetailsProto(log.Errors),
+		Extra:                  extra,
+		ExpiresAt:              timestampProto(log.ExpiresAt),
+		MessageId:              log.MessageID,
+		Attachments:            attachmentsProto(log.Attachments),
+		GroupId:                log.GroupID,
+	})
+}
+
+// timestampProto converts an optional time into its protobuf message; nil stays nil.
+func timestampProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// proto converts the reference into its protobuf message; nil stays nil.
+func (r *BlobRef) proto() *logpb.BlobRef {
+	if r == nil {
+		return nil
+	}
+	return &logpb.BlobRef{Bucket: r.Bucket, Key: r.Key, Size: r.Size, Sha256: r.SHA256}
+}
+
+// attachmentsProto converts the attachments into their protobuf messages.
+func attachmentsProto(attachments []Attachment) []*logpb.Attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	result := make([]*logpb.Attachment, len(attachments))
+	for i, a := range attachments {
+		result[i] = &logpb.Attachment{Name: a.Name, ContentType: a.ContentType, Data: a.Data, Ref: a.Ref.proto()}
+	}
+	return result
+}
+
+// proto converts the level into its protobuf enum value.
+func (l Level) proto() logpb.Level {
+	switch l {
+	case LevelDebug:
+		return logpb.Level_LEVEL_DEBUG
+	case LevelInfo:
+		return logpb.Level_LEVEL_INFO
+	case LevelWarn:
+		return logpb.Level_LEVEL_WARNING
+	case LevelError:
+		return logpb.Level_LEVEL_ERROR
+	case LevelCritical:
+		return logpb.Level_LEVEL_CRITICAL
+	default:
+		return logpb.Level_LEVEL_UNSPECIFIED
+	}
+}
+
+// extraJSON encodes the extra metadata of a log for the protobuf and Avro encodings, which
+// carry it as a JSON string; empty metadata is an empty string.
+func extraJSON(extra map[string]any) (string, error) {
+	if len(extra) == 0 {
+		return "", nil
+	}
+	body, err := json.Marshal(extra)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal extra metadata: %w", err)
+	}
+	return string(body), nil
+}
+
+// errorDetailsProto converts the error details into their protobuf messages.
+func errorDetailsProto(details []ErrorDetail) []*logpb.ErrorDetail {
+	if len(details) == 0 {
+		return nil
+	}
+	messages := make([]*logpb.ErrorDetail, len(details))
+	for i, detail := range details {
+		messages[i] = &logpb.ErrorDetail{Code: int32(detail.Code), Message: detail.Message}
+	}
+	return messages
+}
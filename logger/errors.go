@@ -0,0 +1,50 @@
+package logger
+
+import "errors"
+
+// ErrorDetail is one of the errors a log reports, see LogRequest.Err.
+type ErrorDetail struct {
+	Code    Errorcode `json:"code,omitempty"` // Error code of the error, if it carries one.
+	Message string    `json:"message"`        // Message of the error.
+}
+
+// coded is implemented by errors carrying an error code.
+type coded interface {
+	Code() Errorcode
+}
+
+// errorCode returns the code of the first error in the chain of err carrying one.
+func errorCode(err error) (Errorcode, bool) {
+	var c coded
+	if errors.As(err, &c) {
+		return c.Code(), true
+	}
+	return 0, false
+}
+
+// errorDetails lists the independent causes of an error joined with errors.Join (or any error
+// unwrapping to several errors), nested joins included. It returns nil for single errors.
+func errorDetails(err error) []ErrorDetail {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+
+	var details []ErrorDetail
+	var walk func(errs []error)
+	walk = func(errs []error) {
+		for _, e := range errs {
+			if e == nil {
+				continue
+			}
+			if nested, ok := e.(interface{ Unwrap() []error }); ok {
+				walk(nested.Unwrap())
+				continue
+			}
+			code, _ := errorCode(e)
+			details = append(details, ErrorDetail{Code: code, Message: e.Error()})
+		}
+	}
+	walk(joined.Unwrap())
+	return details
+}
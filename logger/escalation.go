@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// EscalationConfig configures the escalation of repeated error codes, see WithEscalation.
+type EscalationConfig struct {
+	Threshold int           // A code escalates when logged more than Threshold times within Window. Defaults to 10.
+	Window    time.Duration // Sliding window the logs of a code are counted in. Defaults to 5 minutes.
+	Cooldown  time.Duration // Minimal time between two escalations of a code. Defaults to the window.
+	Codes     []Errorcode   // Codes escalated; empty escalates every code.
+	Levels    []Level       // Levels of the counted logs. Defaults to LevelError.
+	Alert     bool          // Hand the escalated logs to the sinks, firing the alert sinks; otherwise they are only published.
+}
+
+// escalator counts the logs of each error code and decides when to escalate.
+type escalator struct {
+	config EscalationConfig
+	mu     sync.Mutex                    // Protects codes.
+	codes  map[Errorcode]*escalationCode // Counting state of the codes logged within the window.
+}
+
+// escalationCode is the counting state of an error code.
+type escalationCode struct {
+	seen      []time.Time // Timestamps of the logs of the code within the window.
+	lastFired time.Time   // Time of the last escalation of the code.
+}
+
+// WithEscalation emits a synthesized Critical log when the same error code is logged more than
+// Threshold times within Window, giving early warning in-process before the consumer-side
+// RulesEngine catches it. The escalated log carries the code, the endpoint of the last log
+// and the count, with the event type EventEscalation. With Alert set, it is also handed to
+// the sinks, so Telegram or paging sinks fire.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "CreatePayment", "/api/v1/payments", nil, nil,
+//		logger.WithEscalation(logger.EscalationConfig{Threshold: 20, Window: 5 * time.Minute, Alert: true}),
+//	)
+func WithEscalation(config EscalationConfig) Option {
+	if config.Threshold <= 0 {
+		config.Threshold = 10
+	}
+	if config.Window <= 0 {
+		config.Window = 5 * time.Minute
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = config.Window
+	}
+	if len(config.Levels) == 0 {
+		config.Levels = []Level{LevelError}
+	}
+	return func(l *logger) {
+		l.escalation = &escalator{config: config, codes: make(map[Errorcode]*escalationCode)}
+	}
+}
+
+// observe counts the log and returns the log escalating its code when the threshold is
+// exceeded. It returns false for a nil escalator.
+func (e *escalator) observe(log *LogRecord, level Level) (LogRequest, bool) {
+	if e == nil || log.Errorcode == 0 || !slices.Contains(e.config.Levels, level) {
+		return LogRequest{}, false
+	}
+	code := Errorcode(log.Errorcode)
+	if len(e.config.Codes) > 0 && !slices.Contains(e.config.Codes, code) {
+		return LogRequest{}, false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, ok := e.codes[code]
+	if !ok {
+		state = &escalationCode{}
+		e.codes[code] = state
+	}
+	now := log.Timestamp
+	cutoff := now.Add(-e.config.Window)
+	state.seen = slices.DeleteFunc(append(state.seen, now), func(t time.Time) bool { return !t.After(cutoff) })
+
+	count := len(state.seen)
+	if count <= e.config.Threshold || now.Sub(state.lastFired) < e.config.Cooldown {
+		return LogRequest{}, false
+	}
+	state.lastFired = now
+
+	return LogRequest{
+		Errorcode:       code,
+		ClientMessageUz: fmt.Sprintf("Xatolik %d takrorlanmoqda: %d marta", code, count),
+		ClientMessageRu: fmt.Sprintf("Ошибка %d повторяется, повторений: %d", code, count),
+		ErrorMessage:    fmt.Sprintf("error code %d logged %d times in %s (threshold %d), last: %s", code, count, e.config.Window, e.config.Threshold, log.ErrorMessage),
+		ApiEndpoint:     log.ApiEndpoint,
+		Method:          log.Method,
+		StatusCode:      log.StatusCode,
+		EventType:       EventEscalation,
+	}, true
+}
+
+// escalate publishes the escalated log at LevelCritical, keeping it from the sinks unless
+// EscalationConfig.Alert is set. It skips the validation, the log being built by observe.
+func (l *logger) escalate(log LogRequest) error {
+	fullLog := getLogRequest()
+	if err := l.populateLogRequest(fullLog, log, LevelCritical.String()); err != nil {
+		putLogRequest(fullLog)
+		return err
+	}
+	fullLog.skipSinks = !l.escalation.config.Alert
+	fullLog.Sequence = l.sequence.Add(1)
+	fullLog.MessageID = l.producerID + "-" + strconv.FormatUint(fullLog.Sequence, 10)
+
+	if l.async != nil {
+		return l.async.enqueue(fullLog, log)
+	}
+	defer putLogRequest(fullLog)
+	return l.send(fullLog)
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"slices"
+	"sync"
+)
+
+// EventType classifies what happened, e.g. "order_created". Event types are registered
+// with RegisterEventType, like error codes are declared, so analytics can rely on a
+// closed set of values (see ValidationPolicy.RegisteredEventTypes).
+type EventType string
+
+// Event types published by the library itself.
+const (
+	// Alert sent by a RulesEngine rule.
+	EventAlertRule EventType = "alert_rule"
+	// Anomaly in the error rates detected by the rollup consumer.
+	EventAnomalyDetected EventType = "anomaly_detected"
+	// Critical log emitted for a repeated error code, see WithEscalation.
+	EventEscalation EventType = "escalation"
+)
+
+// eventTypes holds the registered event types.
+var eventTypes = struct {
+	sync.RWMutex
+	set map[EventType]bool
+}{set: map[EventType]bool{EventAlertRule: true, EventAnomalyDetected: true, EventEscalation: true}}
+
+// RegisterEventType adds event types to the registry. It is usually called from the init
+// function or the declarations of the package defining them.
+//
+// Usage:
+//
+//	const (
+//		EventOrderCreated   logger.EventType = "order_created"
+//		EventOrderCancelled logger.EventType = "order_cancelled"
+//	)
+//
+//	func init() {
+//		logger.RegisterEventType(EventOrderCreated, EventOrderCancelled)
+//	}
+func RegisterEventType(types ...EventType) {
+	eventTypes.Lock()
+	defer eventTypes.Unlock()
+
+	for _, t := range types {
+		eventTypes.set[t] = true
+	}
+}
+
+// Registered reports whether the event type was registered.
+func (t EventType) Registered() bool {
+	eventTypes.RLock()
+	defer eventTypes.RUnlock()
+
+	return eventTypes.set[t]
+}
+
+// EventTypes returns the registered event types, sorted.
+func EventTypes() []EventType {
+	eventTypes.RLock()
+	defer eventTypes.RUnlock()
+
+	types := make([]EventType, 0, len(eventTypes.set))
+	for t := range eventTypes.set {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
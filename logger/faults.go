@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrInjectedFault is returned by publishes failed on purpose, see WithFaultInjection.
+var ErrInjectedFault = errors.New("logger: injected publish fault")
+
+// FaultConfig holds the probabilities of the faults injected into publishes.
+type FaultConfig struct {
+	FailureRate float64       // Probability (0 to 1) that a publish fails with ErrInjectedFault.
+	DelayRate   float64       // Probability (0 to 1) that a publish is delayed before it goes through.
+	Delay       time.Duration // Maximal delay of a delayed publish, picked at random up to it. Defaults to 1s.
+	FaultPing   bool          // Whether Ping fails and is delayed like publishes. Defaults to false, so health checks stay truthful.
+}
+
+// faultTransport is a Transport failing and delaying publishes at random, see WithFaultInjection.
+type faultTransport struct {
+	Transport             // Transport the publishes go through when not failed.
+	config    FaultConfig // Fault probabilities with defaults applied.
+}
+
+// faultBatchTransport is a faultTransport wrapping a BatchTransport.
+type faultBatchTransport struct {
+	*faultTransport
+	batch BatchTransport // Batch publishing of the wrapped transport.
+}
+
+// NewFaultTransport wraps the transport so its publishes fail or are delayed at random.
+// The wrapper keeps batch publishing when the transport supports it; a batch fails or is
+// delayed as a whole. Declare and Close are passed through unchanged, and so is Ping unless
+// FaultPing is set.
+func NewFaultTransport(transport Transport, config FaultConfig) Transport {
+	if config.Delay <= 0 {
+		config.Delay = time.Second
+	}
+	t := &faultTransport{Transport: transport, config: config}
+	if bt, ok := transport.(BatchTransport); ok {
+		return &faultBatchTransport{faultTransport: t, batch: bt}
+	}
+	return t
+}
+
+// Publish injects a fault, then publishes the message through the wrapped transport.
+func (t *faultTransport) Publish(msg Message) error {
+	if err := t.inject(); err != nil {
+		return err
+	}
+	return t.Transport.Publish(msg)
+}
+
+// PublishBatch injects a fault, then publishes the batch through the wrapped transport.
+func (t *faultBatchTransport) PublishBatch(msgs []Message) error {
+	if err := t.inject(); err != nil {
+		return err
+	}
+	return t.batch.PublishBatch(msgs)
+}
+
+// Ping pings the wrapped transport if it implements Pinger, injecting a fault first when
+// FaultPing is set.
+func (t *faultTransport) Ping(ctx context.Context) error {
+	if t.config.FaultPing {
+		if err := t.inject(); err != nil {
+			return err
+		}
+	}
+	if pinger, ok := t.Transport.(Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// inject sleeps for a delayed publish and returns ErrInjectedFault for a failed one.
+func (t *faultTransport) inject() error {
+	if t.config.DelayRate > 0 && rand.Float64() < t.config.DelayRate {
+		time.Sleep(rand.N(t.config.Delay) + 1)
+	}
+	if t.config.FailureRate > 0 && rand.Float64() < t.config.FailureRate {
+		return ErrInjectedFault
+	}
+	return nil
+}
+
+// WithFaultInjection makes publishes fail or hang at random, to check in tests and staging that
+// the service copes with a misbehaving logging pipeline: fallbacks kick in, circuit breakers
+// open and request latency stays bounded. The faults apply to every publish, logs and orders
+// alike, and to the transport left by the other options (e.g. WithDryRun), whatever their order.
+// Health checks (Logger.Ping) only get faults with FaultConfig.FaultPing.
+// Never enable it in production.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", nil, nil,
+//		logger.WithFaultInjection(logger.FaultConfig{FailureRate: 0.2, DelayRate: 0.1, Delay: 3 * time.Second}),
+//	)
+func WithFaultInjection(config FaultConfig) Option {
+	return func(l *logger) {
+		l.faults = &config
+	}
+}
@@ -0,0 +1,40 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// pingTransport is a captureTransport answering pings.
+type pingTransport struct {
+	captureTransport
+	pings int
+}
+
+func (t *pingTransport) Ping(context.Context) error {
+	t.pings++
+	return nil
+}
+
+func TestFaultTransportPing(t *testing.T) {
+	inner := &pingTransport{}
+	transport := logger.NewFaultTransport(inner, logger.FaultConfig{FailureRate: 1})
+
+	if err := transport.Publish(logger.Message{Destination: "logs"}); !errors.Is(err, logger.ErrInjectedFault) {
+		t.Errorf("Publish returned %v, want ErrInjectedFault", err)
+	}
+	if err := transport.(logger.Pinger).Ping(context.Background()); err != nil {
+		t.Errorf("Ping returned %v without FaultPing", err)
+	}
+	if inner.pings != 1 {
+		t.Errorf("wrapped transport pinged %d times, want 1", inner.pings)
+	}
+
+	transport = logger.NewFaultTransport(inner, logger.FaultConfig{FailureRate: 1, FaultPing: true})
+	if err := transport.(logger.Pinger).Ping(context.Background()); !errors.Is(err, logger.ErrInjectedFault) {
+		t.Errorf("Ping returned %v with FaultPing, want ErrInjectedFault", err)
+	}
+}
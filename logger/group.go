@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// groupLogger is a Logger stamping a group ID on the logs of its parent.
+type groupLogger struct {
+	Logger
+	id string // Group ID stamped on the logs without one.
+}
+
+// NewGroupLogger returns a logger stamping the group ID on the logs without one (see
+// LogRequest.GroupID) and publishing them through the parent. The other methods, Flush and
+// Close included, act on the parent. It lets Logger implementations other than the one of
+// this package (e.g. mocks) implement Group.
+func NewGroupLogger(parent Logger, groupID string) Logger {
+	return &groupLogger{Logger: parent, id: groupID}
+}
+
+// NewGroupID returns a random group ID, like those of Logger.Group.
+func NewGroupID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// Group returns a logger stamping a newly generated group ID on the logs of the logger.
+//
+// Usage:
+//
+//	group := log.Group()
+//	for _, product := range products {
+//		if err := importProduct(product); err != nil {
+//			group.Error(logger.LogRequest{Errorcode: logger.ErrInvalidData, Err: err})
+//		}
+//	}
+//	group.Info(logger.LogRequest{Errorcode: logger.InfoRequestProcessed, ClientMessageUz: "Import tugadi"})
+func (l *logger) Group() Logger {
+	return NewGroupLogger(l, NewGroupID())
+}
+
+// stamp sets the group ID of the log unless it has one.
+func (g *groupLogger) stamp(log LogRequest) LogRequest {
+	if log.GroupID == "" {
+		log.GroupID = g.id
+	}
+	return log
+}
+
+// Debug logs a debugging message of the group.
+func (g *groupLogger) Debug(log LogRequest) error {
+	return g.Logger.Debug(g.stamp(log))
+}
+
+// Info logs an informational message of the group.
+func (g *groupLogger) Info(log LogRequest) error {
+	return g.Logger.Info(g.stamp(log))
+}
+
+// Warn logs a warning message of the group.
+func (g *groupLogger) Warn(log LogRequest) error {
+	return g.Logger.Warn(g.stamp(log))
+}
+
+// Error logs an error message of the group.
+func (g *groupLogger) Error(log LogRequest) error {
+	return g.Logger.Error(g.stamp(log))
+}
+
+// Critical logs a critical error of the group.
+func (g *groupLogger) Critical(log LogRequest) error {
+	return g.Logger.Critical(g.stamp(log))
+}
+
+// LogWithID logs a message of the group with the level and returns its message ID.
+func (g *groupLogger) LogWithID(level Level, log LogRequest) (string, error) {
+	return g.Logger.LogWithID(level, g.stamp(log))
+}
+
+// DebugContext logs a debugging message of the group enriched with the metadata of the context.
+func (g *groupLogger) DebugContext(ctx context.Context, log LogRequest) error {
+	return g.Logger.DebugContext(ctx, g.stamp(log))
+}
+
+// InfoContext logs an informational message of the group enriched with the metadata of the context.
+func (g *groupLogger) InfoContext(ctx context.Context, log LogRequest) error {
+	return g.Logger.InfoContext(ctx, g.stamp(log))
+}
+
+// WarnContext logs a warning message of the group enriched with the metadata of the context.
+func (g *groupLogger) WarnContext(ctx context.Context, log LogRequest) error {
+	return g.Logger.WarnContext(ctx, g.stamp(log))
+}
+
+// ErrorContext logs an error message of the group enriched with the metadata of the context.
+func (g *groupLogger) ErrorContext(ctx context.Context, log LogRequest) error {
+	return g.Logger.ErrorContext(ctx, g.stamp(log))
+}
+
+// CriticalContext logs a critical error of the group enriched with the metadata of the context.
+func (g *groupLogger) CriticalContext(ctx context.Context, log LogRequest) error {
+	return g.Logger.CriticalContext(ctx, g.stamp(log))
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// UnaryServerInterceptor injects the metadata of every unary gRPC call into its context, like
+// Middleware does for HTTP: the request ID (from x-request-id, generated when missing and sent
+// back in the response header), the full method as the endpoint, the client IP (see
+// WithTrustedProxies) and the User-Agent.
+//
+// Usage:
+//
+//	proxies := logger.WithTrustedProxies(netip.MustParsePrefix("10.0.0.0/8"))
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(logger.UnaryServerInterceptor(proxies)),
+//		grpc.ChainStreamInterceptor(logger.StreamServerInterceptor(proxies)),
+//	)
+func UnaryServerInterceptor(opts ...MiddlewareOption) grpc.UnaryServerInterceptor {
+	config := newMiddlewareConfig(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(config.injectCall(ctx, info.FullMethod), req)
+	}
+}
+
+// StreamServerInterceptor injects the metadata of every streaming gRPC call into the context of
+// its stream, see UnaryServerInterceptor.
+func StreamServerInterceptor(opts ...MiddlewareOption) grpc.StreamServerInterceptor {
+	config := newMiddlewareConfig(opts)
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &metadataStream{ServerStream: stream, ctx: config.injectCall(stream.Context(), info.FullMethod)})
+	}
+}
+
+// metadataStream is a server stream whose context carries the metadata of the call.
+type metadataStream struct {
+	grpc.ServerStream
+	ctx context.Context // Context of the call with the injected metadata.
+}
+
+// Context returns the context of the call with the injected metadata.
+func (s *metadataStream) Context() context.Context {
+	return s.ctx
+}
+
+// injectCall returns a copy of the context of the gRPC call carrying its metadata.
+func (c middlewareConfig) injectCall(ctx context.Context, fullMethod string) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+
+	requestID := first("x-request-id")
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	// Fails only outside of a gRPC server, where there is no response to send the ID with.
+	_ = grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestID))
+
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	return InjectMetadata(ctx, ContextMetadata{
+		RequestID:   requestID,
+		ApiEndpoint: fullMethod,
+		ClientIP:    clientAddress(remoteAddr, md.Get("x-forwarded-for"), first("x-real-ip"), c.trustedProxies),
+		UserAgent:   first("user-agent"),
+	})
+}
@@ -0,0 +1,69 @@
+package logger
+
+import "fmt"
+
+// Level is the severity of a log message.
+type Level int
+
+// Log levels in increasing order of severity.
+const (
+	LevelInfo Level = iota
+	LevelWarn
+	LevelError
+	LevelCritical
+
+	// LevelDebug is below LevelInfo, so the zero Level stays LevelInfo. Debug logs are
+	// discarded unless enabled with WithMinLevel.
+	LevelDebug Level = -1
+)
+
+// String returns the level name as it appears in the `error_level` field of published logs.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warning"
+	case LevelError:
+		return "error"
+	case LevelCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// ParseLevel converts a level name (as published in `error_level`) into a Level.
+func ParseLevel(name string) (Level, error) {
+	switch name {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warning", "warn":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "critical":
+		return LevelCritical, nil
+	default:
+		return 0, fmt.Errorf("unknown log level: %q", name)
+	}
+}
+
+// MarshalText encodes the level as its name, so levels read naturally in JSON and YAML.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a level name.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
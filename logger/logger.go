@@ -1,33 +1,108 @@
 package logger
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
-	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 // Logger is the main interface for logging operations.
 // It provides methods to log messages with different severity levels and manage the RabbitMQ connection.
 type Logger interface {
+	// Debug logs debugging messages, discarded unless enabled with WithMinLevel.
+	Debug(log LogRequest) error
+
 	// Info logs informational messages.
 	Info(log LogRequest) error
 
 	// Warn logs warning messages.
 	Warn(log LogRequest) error
 
-	// Error logs error messages.
+	// Error logs error messages. Under WithCodedErrors it always returns a *CodedError.
 	Error(log LogRequest) error
 
-	// Critical logs critical errors.
+	// Critical logs critical errors. Under WithCodedErrors it always returns a *CodedError.
 	Critical(log LogRequest) error
 
+	// LogWithID logs the message with the level like the level methods and returns its message
+	// ID (published in the `message_id` field), so callers can correlate an application event
+	// with its record in the log store. The ID is empty for logs discarded by level.
+	LogWithID(level Level, log LogRequest) (string, error)
+
+	// Group returns a logger stamping a newly generated ID (published in the `group_id` field)
+	// on all its logs, so the logs of one logical operation, e.g. an import of 10k products,
+	// can be reassembled by consumers. The returned logger shares the connection and settings.
+	Group() Logger
+
+	// Enabled reports whether logs of the level are published, so callers can skip building
+	// expensive logs that would be discarded.
+	Enabled(level Level) bool
+
+	// MinLevel returns the minimal level of the published logs, see WithMinLevel.
+	MinLevel() Level
+
+	// SetMinLevel changes the minimal level at runtime, e.g. to enable debug logs during an
+	// incident. It is safe to call concurrently with logging, see Reloader.
+	SetMinLevel(level Level)
+
+	// EnableSink resumes handing logs to the attached sink with the name (see Sink.Name).
+	// It returns ErrUnknownSink when no such sink is attached.
+	EnableSink(name string) error
+
+	// DisableSink stops handing logs to the attached sink with the name, e.g. to cut off the
+	// Telegram sink during an alert storm. Publishing to the broker is not affected.
+	DisableSink(name string) error
+
+	// SinkEnabled reports whether the attached sink with the name receives logs.
+	SinkEnabled(name string) bool
+
+	// DebugContext logs a debugging message enriched with the metadata of the context.
+	DebugContext(ctx context.Context, log LogRequest) error
+
+	// InfoContext logs an informational message enriched with the metadata of the context (see InjectMetadata).
+	InfoContext(ctx context.Context, log LogRequest) error
+
+	// WarnContext logs a warning message enriched with the metadata of the context.
+	WarnContext(ctx context.Context, log LogRequest) error
+
+	// ErrorContext logs an error message enriched with the metadata of the context.
+	ErrorContext(ctx context.Context, log LogRequest) error
+
+	// CriticalContext logs a critical error enriched with the metadata of the context.
+	CriticalContext(ctx context.Context, log LogRequest) error
+
 	OrderNotification(order Order) error
 
 	SendOrderToBitrix(order BitrixOrder) error
+
+	// Ping checks that the broker accepts messages, e.g. for a readiness probe. It returns nil
+	// for transports unable to check their connection, see Pinger.
+	Ping(ctx context.Context) error
+
+	// Diagnostics returns a report of the state of the logger (deliveries, buffered and dropped
+	// logs, last error, settings), to debug missing logs. See WithDiagnosticsReport.
+	Diagnostics() Diagnostics
+
+	// Stats returns the counters of published, failed, dropped and retried logs, e.g. to check
+	// in async mode that logs left the process. See also logmetrics.
+	Stats() Stats
+
+	// Dropped returns the number of logs dropped because the async buffer was full (see WithAsync).
+	Dropped() uint64
+
+	// Flush publishes the logs buffered in async mode, waiting at most until the context is done.
+	Flush(ctx context.Context) error
+
+	// Close publishes the buffered logs and closes the attached sinks, waiting at most until the context is done.
+	Close(ctx context.Context) error
 }
 
 // NewLogger initializes and returns a new Logger instance.
@@ -36,13 +111,19 @@ type Logger interface {
 // - queueName: Name of the RabbitMQ queue where logs will be sent.
 // - functionName: Name of the function generating logs.
 // - apiEndpoint: API endpoint associated with the logs.
-func NewLogger(rabbitMQ rabbitmq.RabbitMQ, queueName, funtionName, apiEndpoint string, orderQueue, bitrixOrderQueue *string) (Logger, error) {
-
-	err := rabbitMQ.DeclareQueue(queueName, true, true, false, false, amqp.Table{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to declare queue: %s", err)
-	}
+// - opts: Optional settings, see Option.
+func NewLogger(rabbitMQ rabbitmq.RabbitMQ, queueName, funtionName, apiEndpoint string, orderQueue, bitrixOrderQueue *string, opts ...Option) (Logger, error) {
+	return NewLoggerWithTransport(NewRabbitMQTransport(rabbitMQ), queueName, funtionName, apiEndpoint, orderQueue, bitrixOrderQueue, opts...)
+}
 
+// NewLoggerWithTransport initializes and returns a new Logger instance publishing through the given transport.
+// Parameters:
+// - transport: Transport used to deliver messages (RabbitMQ, Kafka, ...).
+// - queueName: Name of the queue or topic where logs will be sent.
+// - functionName: Name of the function generating logs.
+// - apiEndpoint: API endpoint associated with the logs.
+// - opts: Optional settings, see Option.
+func NewLoggerWithTransport(transport Transport, queueName, funtionName, apiEndpoint string, orderQueue, bitrixOrderQueue *string, opts ...Option) (Logger, error) {
 	var oQueue string
 	var bitrixOQueue string
 	if orderQueue != nil {
@@ -53,118 +134,429 @@ func NewLogger(rabbitMQ rabbitmq.RabbitMQ, queueName, funtionName, apiEndpoint s
 		bitrixOQueue = *bitrixOrderQueue
 	}
 
-	return &logger{
-		rabbitmq:         rabbitMQ,
-		queue:            queueName,
-		orderQueue:       oQueue,
-		bitrixOrderQueue: bitrixOQueue,
-		functionName:     funtionName,
-		apiEndpoint:      apiEndpoint,
-	}, nil
-}
+	l := &logger{
+		transport:         transport,
+		queue:             queueName,
+		orderQueue:        oQueue,
+		bitrixOrderQueue:  bitrixOQueue,
+		functionName:      funtionName,
+		apiEndpoint:       apiEndpoint,
+		validation:        defaultValidation,
+		producerID:        newProducerID(),
+		defaultStatusCode: 200,
+		metadata:          DetectMetadata(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if _, ok := l.transport.(*rabbitMQTransport); ok && l.encoding != EncodingJSON {
+		_ = l.Close(context.Background())
+		return nil, fmt.Errorf("%w: %s, the rabbitmq client publishes every message as application/json; use NewAMQPTransport", ErrUnsupportedEncoding, l.encoding)
+	}
+	if l.faults != nil {
+		l.transport = NewFaultTransport(l.transport, *l.faults)
+	}
 
-// Info logs an informational message.
-func (l *logger) Info(log LogRequest) error {
-	fullLog, err := l.populateLogRequest(log, "info")
-	if err != nil {
-		return err
+	// Checked and declared once the options are applied, as they may rename the queues (see WithNamespace).
+	if l.strictQueueNames {
+		if err := l.validateQueues(); err != nil {
+			_ = l.Close(context.Background())
+			return nil, err
+		}
 	}
+	for _, queue := range append([]string{l.queue}, l.routedQueues()...) {
+		if err := l.transport.Declare(queue); err != nil {
+			_ = l.Close(context.Background())
+			return nil, fmt.Errorf("failed to declare queue: %s", err)
+		}
+	}
+	if l.startupCheck > 0 {
+		if err := l.checkStartup(); err != nil {
+			_ = l.Close(context.Background())
+			return nil, err
+		}
+	}
+
+	l.static = newStaticSegments(l.functionName, l.apiEndpoint)
+	if l.report != nil {
+		go l.report.run(l)
+	}
+
+	return l, nil
+}
 
-	if err := validateLogRequest(fullLog); err != nil {
+// validateQueues checks the names of the log queue and of the configured order and routed queues.
+func (l *logger) validateQueues() error {
+	if err := ValidateQueueName(l.queue); err != nil {
 		return err
 	}
+	for _, queue := range append([]string{l.orderQueue, l.bitrixOrderQueue}, l.routedQueues()...) {
+		if queue == "" {
+			continue
+		}
+		if err := ValidateQueueName(queue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	return l.rabbitmq.PublishMessage(l.queue, "", fullLog)
+// newProducerID returns a random ID telling apart the sequence numbers of logger instances,
+// including those of the same service restarted or running on several replicas.
+func newProducerID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// Debug logs a debugging message.
+func (l *logger) Debug(log LogRequest) error {
+	return l.log(log, LevelDebug)
+}
+
+// Info logs an informational message.
+func (l *logger) Info(log LogRequest) error {
+	return l.log(log, LevelInfo)
 }
 
 // Warn logs a warning message.
 func (l *logger) Warn(log LogRequest) error {
-	fullLog, err := l.populateLogRequest(log, "warning")
-	if err != nil {
+	return l.log(log, LevelWarn)
+}
+
+// Error logs an error message.
+func (l *logger) Error(log LogRequest) error {
+	return l.logError(log, LevelError)
+}
+
+// Critical logs a critical error message.
+func (l *logger) Critical(log LogRequest) error {
+	return l.logError(log, LevelCritical)
+}
+
+// Enabled reports whether logs of the level are published.
+func (l *logger) Enabled(level Level) bool {
+	return level >= Level(l.minLevel.Load())
+}
+
+// MinLevel returns the minimal level of the published logs.
+func (l *logger) MinLevel() Level {
+	return Level(l.minLevel.Load())
+}
+
+// SetMinLevel changes the minimal level of the published logs.
+func (l *logger) SetMinLevel(level Level) {
+	l.minLevel.Store(int32(level))
+}
+
+// DebugContext logs a debugging message with the metadata of the context.
+func (l *logger) DebugContext(ctx context.Context, log LogRequest) error {
+	return l.log(log.WithContext(ctx), LevelDebug)
+}
+
+// InfoContext logs an informational message with the metadata of the context.
+func (l *logger) InfoContext(ctx context.Context, log LogRequest) error {
+	return l.log(log.WithContext(ctx), LevelInfo)
+}
+
+// WarnContext logs a warning message with the metadata of the context.
+func (l *logger) WarnContext(ctx context.Context, log LogRequest) error {
+	return l.log(log.WithContext(ctx), LevelWarn)
+}
+
+// ErrorContext logs an error message with the metadata of the context.
+func (l *logger) ErrorContext(ctx context.Context, log LogRequest) error {
+	return l.logError(log.WithContext(ctx), LevelError)
+}
+
+// CriticalContext logs a critical error message with the metadata of the context.
+func (l *logger) CriticalContext(ctx context.Context, log LogRequest) error {
+	return l.logError(log.WithContext(ctx), LevelCritical)
+}
+
+func (l *logger) OrderNotification(order Order) error {
+	return l.publish(l.orderQueue, "", EncodingJSON, order)
+}
+
+func (l *logger) SendOrderToBitrix(order BitrixOrder) error {
+	return l.publish(l.bitrixOrderQueue, "", EncodingJSON, order)
+}
+
+// logError logs an error or critical message, returning a *CodedError under WithCodedErrors.
+func (l *logger) logError(log LogRequest, level Level) error {
+	err := l.log(log, level)
+	if !l.codedErrors {
 		return err
 	}
+	return l.codedError(log, err)
+}
 
-	if err := validateLogRequest(fullLog); err != nil {
-		return err
+// log populates and validates a log message with the given level, then publishes it
+// (or enqueues it in async mode). Logs below the minimal level are discarded.
+func (l *logger) log(log LogRequest, level Level) error {
+	_, err := l.logID(log, level)
+	return err
+}
+
+// logID logs the message like log and returns its message ID, empty for discarded logs.
+func (l *logger) logID(log LogRequest, level Level) (string, error) {
+	if !l.Enabled(level) {
+		return "", nil
+	}
+
+	fullLog := getLogRequest()
+
+	if err := l.populateLogRequest(fullLog, log, level.String()); err != nil {
+		putLogRequest(fullLog)
+		return "", err
 	}
 
-	return l.rabbitmq.PublishMessage(l.queue, "", fullLog)
+	if err := l.validation.validate(fullLog, log, level); err != nil {
+		if !l.validation.publishInvalid {
+			putLogRequest(fullLog)
+			return "", err
+		}
+		fullLog.ValidationFailed, fullLog.ValidationError = true, err.Error()
+	}
+	fullLog.Sequence = l.sequence.Add(1)
+	// The producer ID and the sequence number already identify the log uniquely.
+	fullLog.MessageID = l.producerID + "-" + strconv.FormatUint(fullLog.Sequence, 10)
+	id := fullLog.MessageID
+	// Built before sending, as the log is reused once published.
+	escalated, escalate := l.escalation.observe(fullLog, level)
+
+	var err error
+	if l.async != nil {
+		err = l.async.enqueue(fullLog, log)
+	} else {
+		err = l.send(fullLog)
+		putLogRequest(fullLog)
+	}
+	if escalate {
+		_ = l.escalate(escalated)
+	}
+	return id, err
 }
 
-// Error logs an error message.
-func (l *logger) Error(log LogRequest) error {
-	fullLog, err := l.populateLogRequest(log, "error")
-	if err != nil {
-		return err
+// LogWithID logs the message with the level and returns its message ID.
+func (l *logger) LogWithID(level Level, log LogRequest) (string, error) {
+	id, err := l.logID(log, level)
+	if l.codedErrors && level >= LevelError {
+		err = l.codedError(log, err)
 	}
+	return id, err
+}
 
-	if err := validateLogRequest(fullLog); err != nil {
-		return err
+// send publishes a populated log and hands it to the attached sinks.
+func (l *logger) send(fullLog *LogRecord) error {
+	msg, buf, err := l.prepare(fullLog)
+	if err == nil {
+		err = l.deliver(msg)
+		putEncodeBuffer(buf)
 	}
+	l.writeSinks(*fullLog)
 
-	return l.rabbitmq.PublishMessage(l.queue, "", fullLog)
+	return err
 }
 
-// Critical logs a critical error message.
-func (l *logger) Critical(log LogRequest) error {
-	fullLog, err := l.populateLogRequest(log, "critical")
+// prepare offloads and compresses the payloads of a populated log and encodes it.
+// The buffer must be returned with putEncodeBuffer once the message is published.
+func (l *logger) prepare(fullLog *LogRecord) (Message, *encodeBuffer, error) {
+	if fullLog.payload != nil {
+		body, err := json.Marshal(fullLog.payload)
+		if err != nil {
+			return Message{}, nil, fmt.Errorf("failed to marshal request payload: %w", err)
+		}
+		fullLog.RequestPayload, fullLog.payload = string(body), nil
+	}
+	sanitizePayloads(fullLog)
+	// Offloaded before compressing, so the store keeps the payloads as they are and the
+	// emptied fields are not flagged as compressed.
+	if l.offload != nil {
+		l.offloadPayloads(fullLog)
+	}
+	if l.compressThreshold > 0 {
+		l.compressPayloads(fullLog)
+	}
+
+	var message any = fullLog
+	if l.rawPayload && l.encoding == EncodingJSON && json.Valid([]byte(fullLog.RequestPayload)) {
+		message = rawPayloadLog{LogRecord: fullLog, RequestPayload: json.RawMessage(fullLog.RequestPayload)}
+	}
+
+	msg, buf, err := l.encodeMessage(l.destination(fullLog), fullLog.ErrorLevel, l.encoding, message)
+	if err == nil {
+		msg.ID = fullLog.MessageID
+		if fullLog.ExpiresAt != nil {
+			msg.ExpiresAt = *fullLog.ExpiresAt
+		}
+	}
+	return msg, buf, err
+}
+
+// publish encodes the message with the encoding into a pooled buffer and hands it to the transport.
+func (l *logger) publish(destination, level string, encoding Encoding, message any) error {
+	msg, buf, err := l.encodeMessage(destination, level, encoding, message)
 	if err != nil {
 		return err
 	}
+	defer putEncodeBuffer(buf)
 
-	if err := validateLogRequest(fullLog); err != nil {
-		return err
+	return l.deliver(msg)
+}
+
+// deliver hands the message to the transport, retrying it with WithPublishRetry, and records
+// the outcome, see Diagnostics.
+func (l *logger) deliver(msg Message) error {
+	err := l.withRetry(1, func() error { return l.transport.Publish(msg) })
+	l.stats.record(err, 1)
+	return err
+}
+
+// encodeMessage encodes the message into a pooled buffer. The buffer must be returned
+// with putEncodeBuffer once the message is published.
+func (l *logger) encodeMessage(destination, level string, encoding Encoding, message any) (Message, *encodeBuffer, error) {
+	buf := getEncodeBuffer()
+
+	var body []byte
+	var err error
+	switch encoding {
+	case EncodingMsgPack:
+		body, err = buf.encodeMsgPack(message)
+	case EncodingProtobuf:
+		body, err = buf.encodeProto(message)
+	case EncodingAvro:
+		body, err = buf.encodeAvro(l.avro, message)
+	default:
+		body, err = buf.encode(message)
+	}
+	if err != nil {
+		putEncodeBuffer(buf)
+		return Message{}, nil, err
 	}
 
-	return l.rabbitmq.PublishMessage(l.queue, "", fullLog)
+	return Message{
+		Destination: destination,
+		Level:       level,
+		Headers:     capabilityHeaders()[encoding],
+		ContentType: encoding.ContentType(),
+		Body:        body,
+	}, buf, nil
 }
 
-func (l *logger) OrderNotification(order Order) error {
-	return l.rabbitmq.PublishMessage(l.orderQueue, "", order)
+// ErrInvalidLog is wrapped by the errors returned for logs missing required fields.
+var ErrInvalidLog = errors.New("invalid log")
+
+// Validation errors, all wrapping ErrInvalidLog.
+var (
+	ErrMissingErrorCode     = fmt.Errorf("%w: error_code is required", ErrInvalidLog)
+	ErrMissingClientMessage = fmt.Errorf("%w: at least one client message (Uz or Ru) is required", ErrInvalidLog)
+	ErrMissingLevel         = fmt.Errorf("%w: error level is required", ErrInvalidLog)
+	ErrMissingPayload       = fmt.Errorf("%w: request payload is required for this error level", ErrInvalidLog)
+	ErrMissingField         = fmt.Errorf("%w: field is required for this error level", ErrInvalidLog)
+	ErrInvalidMethod        = fmt.Errorf("%w: unknown HTTP method", ErrInvalidLog)
+	ErrUnknownEventType     = fmt.Errorf("%w: event type is not registered", ErrInvalidLog)
+)
+
+// ValidationError reports every problem of an invalid log at once.
+// errors.Is matches it against each of its errors, and thereby against ErrInvalidLog.
+type ValidationError struct {
+	Errors []error // Validation errors, e.g. ErrMissingErrorCode.
 }
 
-func (l *logger) SendOrderToBitrix(order BitrixOrder) error {
-	return l.rabbitmq.PublishMessage(l.bitrixOrderQueue, "", order)
+// Error joins the messages of the validation errors.
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the validation errors.
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// Is matches ErrInvalidLog, also for errors of custom validators.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidLog
 }
 
-// validateLogRequest ensures that required fields in the log request are present.
-func validateLogRequest(log logRequest) error {
+// validate ensures that required fields in the log request are present and runs the custom validators.
+// It returns a *ValidationError listing all problems.
+func (v *validation) validate(log *LogRecord, source LogRequest, level Level) error {
+	var errs []error
 	if log.Errorcode == 0 {
-		return errors.New("error_code is required")
+		errs = append(errs, ErrMissingErrorCode)
 	}
 
-	if log.ClientMessageUz == "" && log.ClientMessageRu == "" {
-		return errors.New("at least one client message (Uz or Ru) is required")
+	if log.ClientMessageUz == "" && log.ClientMessageRu == "" && !v.allowEmptyClientMessage {
+		errs = append(errs, ErrMissingClientMessage)
 	}
 
-	if log.ErrorLevel == "" || ((log.ErrorLevel == "error" || log.ErrorLevel == "critical") && log.RequestPayload == "") {
-		return errors.New("request payload is required for this error level")
+	if log.ErrorLevel == "" {
+		errs = append(errs, ErrMissingLevel)
+	}
+	for _, field := range v.requiredFields[log.ErrorLevel] {
+		switch {
+		case field.present(log):
+		case field == FieldRequestPayload:
+			errs = append(errs, ErrMissingPayload)
+		default:
+			errs = append(errs, fmt.Errorf("%w: %s", ErrMissingField, field))
+		}
 	}
 
+	if log.Method != "" && v.methods != nil && !v.methods[log.Method] {
+		errs = append(errs, fmt.Errorf("%w: %q", ErrInvalidMethod, log.Method))
+	}
+
+	if v.registeredEventTypes && source.EventType != "" && !source.EventType.Registered() {
+		errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownEventType, source.EventType))
+	}
+
+	for _, validator := range v.validators {
+		if err := validator(level, source); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
 	return nil
 }
 
-// populateLogRequest populates a `logRequest` with additional metadata like timestamp, error level, and function name.
-func (l *logger) populateLogRequest(log LogRequest, errorLevel string) (logRequest, error) {
+// populateLogRequest populates a `LogRecord` with additional metadata like timestamp, error level, and function name.
+func (l *logger) populateLogRequest(dst *LogRecord, log LogRequest, errorLevel string) error {
 
-	var (
-		body []byte
-		err  error
-	)
-
-	switch msg := log.RequestPayload.(type) {
+	var payload string
+	var pending any
+	request := log.RequestPayload
+	if f, ok := request.(PayloadFunc); ok {
+		request = f()
+	}
+	switch msg := request.(type) {
 	case []byte:
-		body = msg
+		payload = string(msg)
 	case string:
-		body = []byte(msg)
+		payload = msg
+	case nil:
+		payload = "null"
 	default:
-		body, err = json.Marshal(msg)
+		if l.async != nil && l.async.config.DeferMarshal {
+			pending = msg
+			break
+		}
+		body, err := json.Marshal(msg)
 		if err != nil {
-			return logRequest{}, err
+			return err
 		}
+		payload = string(body)
 	}
 
-	logRequest := logRequest{
+	*dst = LogRecord{
 		Timestamp:       time.Now(),
 		ErrorLevel:      errorLevel,
 		Errorcode:       int(log.Errorcode),
@@ -174,21 +566,66 @@ func (l *logger) populateLogRequest(log LogRequest, errorLevel string) (logReque
 		DetailsUz:       log.DetailsUz,
 		DetailsRu:       log.DetailsRu,
 		ApiEndpoint:     log.ApiEndpoint,
-		Method:          log.Method,
+		Method:          normalizeMethod(log.Method),
 		FunctionName:    l.functionName,
 		StatusCode:      log.StatusCode,
-		RequestPayload:  string(body),
-		EventType:       log.EventType,
+		RequestPayload:  payload,
+		EventType:       string(log.EventType),
 		ResponseData:    log.ResponseData,
 		MerchantApiKey:  log.MerchantApiKey,
+		DurationMs:      log.DurationMs,
+		ProducerID:      l.producerID,
+		SchemaVersion:   SchemaVersion,
+		Host:            l.metadata.Host,
+		Pod:             l.metadata.Pod,
+		Service:         l.metadata.Service,
+		InstanceID:      l.metadata.InstanceID,
+		Environment:     l.metadata.Environment,
+		AppVersion:      l.metadata.AppVersion,
+		GitCommit:       l.metadata.GitCommit,
+		UserID:          log.UserID,
+		SessionID:       log.SessionID,
+		ClientIP:        log.ClientIP,
+		UserAgent:       log.UserAgent,
+		RequestID:       log.RequestID,
+		Extra:           log.Extra,
+		Attachments:     log.Attachments,
+		GroupID:         log.GroupID,
+		static:          l.static,
+		payload:         pending,
+	}
+	if log.Err != nil {
+		if dst.ErrorMessage == "" {
+			dst.ErrorMessage = log.Err.Error()
+		}
+		if code, ok := errorCode(log.Err); ok && dst.Errorcode == 0 {
+			dst.Errorcode = int(code)
+		}
+		dst.Errors = errorDetails(log.Err)
+	}
+	if log.TTL > 0 {
+		expiresAt := dst.Timestamp.Add(log.TTL)
+		dst.ExpiresAt = &expiresAt
 	}
-	// Fallbacks for missing API endpoint or status code.
+	if l.translate != nil && dst.Errorcode != 0 && dst.ClientMessageUz == "" && dst.ClientMessageRu == "" {
+		dst.ClientMessageUz = l.translate(Errorcode(dst.Errorcode), LangUz)
+		dst.ClientMessageRu = l.translate(Errorcode(dst.Errorcode), LangRu)
+	}
+	// Fallbacks for missing API endpoint or status code, and protection of the merchant key.
 	if log.ApiEndpoint == "" {
-		logRequest.ApiEndpoint = l.apiEndpoint
+		dst.ApiEndpoint = l.apiEndpoint
+	}
+	if l.merchantKey != nil && log.MerchantApiKey != "" {
+		dst.MerchantApiKey = l.merchantKey(log.MerchantApiKey)
 	}
 	if log.StatusCode == 0 {
-		logRequest.StatusCode = 200
+		dst.StatusCode = l.defaultStatusCode
+		if errorLevel == "error" || errorLevel == "critical" {
+			if status := log.Errorcode.HTTPStatus(); status != 0 {
+				dst.StatusCode = status
+			}
+		}
 	}
 
-	return logRequest, nil
+	return nil
 }
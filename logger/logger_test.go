@@ -0,0 +1,61 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// discardTransport drops the published messages.
+type discardTransport struct{}
+
+func (discardTransport) Declare(string) error         { return nil }
+func (discardTransport) Publish(logger.Message) error { return nil }
+func (discardTransport) Close() error                 { return nil }
+
+func BenchmarkInfo(b *testing.B) {
+	log, err := logger.NewLoggerWithTransport(discardTransport{}, "logs", "CreateOrder", "/api/v1/orders", nil, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	request := logger.LogRequest{
+		Errorcode:       logger.InfoRequestProcessed,
+		ClientMessageUz: "Buyurtma yaratildi",
+		ClientMessageRu: "Заказ создан",
+		RequestPayload:  `{"sku":"A-1","qty":1}`,
+		Method:          "POST",
+		StatusCode:      201,
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := log.Info(request); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestStrictQueueNames(t *testing.T) {
+	log, err := logger.NewLoggerWithTransport(discardTransport{}, "Legacy_Logs", "CreateOrder", "/api/v1/orders", nil, nil)
+	if err != nil {
+		t.Fatalf("queue names must not be checked by default: %v", err)
+	}
+	_ = log.Close(context.Background())
+
+	_, err = logger.NewLoggerWithTransport(discardTransport{}, "Legacy_Logs", "CreateOrder", "/api/v1/orders", nil, nil, logger.WithStrictQueueNames())
+	if !errors.Is(err, logger.ErrInvalidQueueName) {
+		t.Errorf("strict queue names returned %v, want ErrInvalidQueueName", err)
+	}
+
+	if _, err := logger.ParseConfig([]byte("broker_url: amqp://localhost\nqueue: Legacy_Logs\nfunction_name: CreateOrder\n")); err != nil {
+		t.Errorf("config queue names must not be checked by default: %v", err)
+	}
+	_, err = logger.ParseConfig([]byte("broker_url: amqp://localhost\nqueue: Legacy_Logs\nfunction_name: CreateOrder\nstrict_queue_names: true\n"))
+	if !errors.Is(err, logger.ErrInvalidQueueName) {
+		t.Errorf("strict config returned %v, want ErrInvalidQueueName", err)
+	}
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// MerchantKeyHashPrefix starts the merchant API keys hashed by WithMerchantKeyHashing.
+const MerchantKeyHashPrefix = "hmac-sha256:"
+
+// HashMerchantApiKey returns the published form of a merchant API key under WithMerchantKeyHashing.
+// Authorized consumers holding the secret use it to look up the logs of a merchant,
+// e.g. in logquery.Query.MerchantApiKey or a merchant forwarder's Resolve function.
+func HashMerchantApiKey(secret []byte, apiKey string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(apiKey))
+	return MerchantKeyHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WithMerchantKeyHashing publishes merchant API keys as their HMAC-SHA256 under the secret
+// (see HashMerchantApiKey) instead of the raw keys, which must not leak to every consumer
+// of the log queue. Logs of one merchant still share the same value.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "CreateOrder", "/orders", nil, nil,
+//		logger.WithMerchantKeyHashing([]byte(os.Getenv("MERCHANT_KEY_SECRET"))),
+//	)
+func WithMerchantKeyHashing(secret []byte) Option {
+	return func(l *logger) {
+		l.merchantKey = func(apiKey string) string {
+			return HashMerchantApiKey(secret, apiKey)
+		}
+	}
+}
+
+// WithMerchantKeyResolver publishes the merchant ID returned by resolve in place of the
+// merchant API key. Keys it cannot resolve are removed rather than published raw.
+// resolve is called for every log with a key, so it should be cached on the caller side.
+func WithMerchantKeyResolver(resolve func(apiKey string) (merchantID string, ok bool)) Option {
+	return func(l *logger) {
+		l.merchantKey = func(apiKey string) string {
+			if id, ok := resolve(apiKey); ok {
+				return id
+			}
+			return ""
+		}
+	}
+}
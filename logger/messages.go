@@ -0,0 +1,201 @@
+package logger
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Languages of the client messages.
+const (
+	LangUz  = "uz"  // Uzbek (Latin script), the default.
+	LangRu  = "ru"  // Russian.
+	LangEn  = "en"  // English.
+	LangKaa = "kaa" // Karakalpak; its messages are registered with SetMessages, falling back to Uzbek.
+)
+
+// ClientMessage is the message shown to clients for an error code, per language.
+type ClientMessage struct {
+	Uz string `json:"uz" yaml:"uz"` // Message in Uzbek.
+	Ru string `json:"ru" yaml:"ru"` // Message in Russian.
+	En string `json:"en" yaml:"en"` // Message in English.
+}
+
+// In returns the message in the language (e.g. "ru" or "ru-RU"), falling back to Uzbek,
+// then Russian, then English when it has no translation.
+func (m ClientMessage) In(lang string) string {
+	var message string
+	switch language(lang) {
+	case LangRu:
+		message = m.Ru
+	case LangEn:
+		message = m.En
+	default:
+		message = m.Uz
+	}
+	for _, fallback := range []string{message, m.Uz, m.Ru, m.En} {
+		if fallback != "" {
+			return fallback
+		}
+	}
+	return ""
+}
+
+// exact returns the message in the language, without falling back to another one.
+func (m ClientMessage) exact(lang string) string {
+	switch lang {
+	case LangUz:
+		return m.Uz
+	case LangRu:
+		return m.Ru
+	case LangEn:
+		return m.En
+	default:
+		return ""
+	}
+}
+
+// languages returns the primary languages of a language tag or Accept-Language value, most
+// preferred first, e.g. ["kaa", "ru"] for "ru;q=0.8, kaa-UZ". Languages with q=0 and the
+// "*" wildcard are left out.
+func languages(accept string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(accept, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		lang := language(tag)
+		if lang == "" || lang == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 && !slices.ContainsFunc(tags, func(w weighted) bool { return w.lang == lang }) {
+			tags = append(tags, weighted{lang: lang, q: q})
+		}
+	}
+	slices.SortStableFunc(tags, func(a, b weighted) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.lang
+	}
+	return result
+}
+
+// language returns the primary language of a language tag or Accept-Language value,
+// e.g. "ru" for "ru-RU,ru;q=0.9".
+func language(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_,;"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// categoryMessages are the client messages of the codes missing from the catalog.
+var categoryMessages = map[Category]ClientMessage{
+	CategoryValidation:     {Uz: "So'rov ma'lumotlari noto'g'ri", Ru: "Неверные данные запроса", En: "Invalid request"},
+	CategoryAuthentication: {Uz: "Avtorizatsiya xatosi", Ru: "Ошибка авторизации", En: "Authentication error"},
+	CategoryResource:       {Uz: "Ma'lumot bilan ishlashda xatolik", Ru: "Ошибка при работе с ресурсом", En: "Resource error"},
+	CategoryIntegration:    {Uz: "Tashqi xizmatda xatolik yuz berdi", Ru: "Ошибка внешнего сервиса", En: "External service error"},
+	CategoryBusiness:       {Uz: "Amalni bajarib bo'lmadi", Ru: "Не удалось выполнить операцию", En: "The operation could not be completed"},
+}
+
+// defaultMessage is the client message of codes without a more specific one.
+var defaultMessage = ClientMessage{Uz: "Xatolik yuz berdi, keyinroq urinib ko'ring", Ru: "Произошла ошибка, попробуйте позже", En: "Something went wrong, please try again later"}
+
+// ClientMessage returns the client message of the error code from the catalog, or the
+// generic message of its category for codes missing from it.
+func (c Errorcode) ClientMessage() ClientMessage {
+	if m, ok := clientMessages[c]; ok {
+		return m
+	}
+	if m, ok := categoryMessages[c.Category()]; ok {
+		return m
+	}
+	return defaultMessage
+}
+
+// APIError is the standard error body returned to API clients.
+type APIError struct {
+	Code    Errorcode `json:"code"`              // Error code.
+	Message string    `json:"message"`           // Client message in the requested language.
+	Details string    `json:"details,omitempty"` // Optional details in the requested language.
+	Status  int       `json:"-"`                 // HTTP status of the response, see Errorcode.HTTPStatus.
+}
+
+// Error returns the message, so API errors can be returned as errors.
+func (e APIError) Error() string {
+	return e.Message
+}
+
+// ErrorResponse returns the error body of the code in the language (e.g. "uz", "ru",
+// or an Accept-Language value like "kaa, ru;q=0.8"), built from the same catalog as the logs
+// (or the translator set with SetTranslator, or the overrides of SetMessages). The most
+// preferred language with a message is used, Uzbek when none has one.
+//
+// Usage:
+//
+//	resp := logger.ErrorResponse(logger.ErrResourceNotFound, r.Header.Get("Accept-Language"))
+//	w.WriteHeader(resp.Status)
+//	_ = json.NewEncoder(w).Encode(resp)
+func ErrorResponse(code Errorcode, lang string) APIError {
+	status := code.HTTPStatus()
+	if status == 0 {
+		status = 500
+	}
+	return APIError{Code: code, Message: translate(nil, code, lang), Status: status}
+}
+
+// WithDetails returns a copy of the error with the details set.
+func (e APIError) WithDetails(details string) APIError {
+	e.Details = details
+	return e
+}
+
+// RespondError logs the error and writes the localized error body of the code to the
+// response, with the status the code maps to. The log carries the code's client messages,
+// the request's endpoint, method, client and context metadata, and the request URI as
+// payload; codes mapping to 5xx are logged as errors, the others as warnings. Logging
+// failures do not affect the response.
+//
+// Usage:
+//
+//	order, err := svc.CreateOrder(r.Context(), req)
+//	if err != nil {
+//		logger.RespondError(w, r, log, logger.ErrInvalidOrderStatus, err)
+//		return
+//	}
+func RespondError(w http.ResponseWriter, r *http.Request, l Logger, code Errorcode, err error) {
+	resp := ErrorResponse(code, r.Header.Get("Accept-Language"))
+
+	log := LogRequest{
+		Errorcode:       code,
+		ClientMessageUz: translate(nil, code, LangUz),
+		ClientMessageRu: translate(nil, code, LangRu),
+		StatusCode:      resp.Status,
+		RequestPayload:  r.URL.RequestURI(),
+		Err:             err,
+	}.WithRequest(r)
+	if resp.Status >= 500 {
+		_ = l.ErrorContext(r.Context(), log)
+	} else {
+		_ = l.WarnContext(r.Context(), log)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.Status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"os"
+	"runtime/debug"
+)
+
+// Metadata identifies the process emitting the logs, so the replica behind an error can be
+// told apart without looking at broker connections.
+type Metadata struct {
+	Host       string // Host name of the machine or container.
+	Pod        string // Kubernetes pod name.
+	Service    string // Name of the service.
+	InstanceID string // ID of the running instance, e.g. the pod UID.
+
+	// Environment the service runs in, e.g. "production" or "staging", so consumers sharing
+	// a queue can filter out the logs of other environments.
+	Environment string
+
+	AppVersion string // Version of the application, e.g. "v1.4.2".
+	GitCommit  string // Git commit the application was built from.
+}
+
+// DetectMetadata reads the metadata of the current process from the environment:
+//   - Host: os.Hostname.
+//   - Pod: POD_NAME, as set through the Kubernetes downward API.
+//   - Service: SERVICE_NAME, or OTEL_SERVICE_NAME.
+//   - InstanceID: POD_UID, or INSTANCE_ID.
+//   - Environment: ENVIRONMENT, or APP_ENV.
+//   - AppVersion: APP_VERSION, or the main module version of the build info.
+//   - GitCommit: GIT_COMMIT, or the VCS revision of the build info.
+//
+// The downward API variables are set in the pod spec:
+//
+//	env:
+//	  - name: POD_NAME
+//	    valueFrom: {fieldRef: {fieldPath: metadata.name}}
+//	  - name: POD_UID
+//	    valueFrom: {fieldRef: {fieldPath: metadata.uid}}
+func DetectMetadata() Metadata {
+	host, _ := os.Hostname()
+	m := Metadata{
+		Host:        host,
+		Pod:         os.Getenv("POD_NAME"),
+		Service:     firstEnv("SERVICE_NAME", "OTEL_SERVICE_NAME"),
+		InstanceID:  firstEnv("POD_UID", "INSTANCE_ID"),
+		Environment: firstEnv("ENVIRONMENT", "APP_ENV"),
+		AppVersion:  os.Getenv("APP_VERSION"),
+		GitCommit:   os.Getenv("GIT_COMMIT"),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if m.AppVersion == "" && info.Main.Version != "(devel)" {
+			m.AppVersion = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && m.GitCommit == "" {
+				m.GitCommit = setting.Value
+			}
+		}
+	}
+	return m
+}
+
+// firstEnv returns the first non-empty of the environment variables.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// WithMetadata replaces the metadata added to every log, which defaults to DetectMetadata.
+// Empty fields are not published, so WithMetadata(Metadata{}) disables the enrichment.
+func WithMetadata(metadata Metadata) Option {
+	return func(l *logger) {
+		l.metadata = metadata
+	}
+}
+
+// WithEnvironment sets the environment added to every log, overriding the one read from
+// the ENVIRONMENT variable. Pass it after WithMetadata, which replaces all metadata.
+func WithEnvironment(environment string) Option {
+	return func(l *logger) {
+		l.metadata.Environment = environment
+	}
+}
+
+// WithAppVersion sets the application version and git commit added to every log, overriding
+// the ones read from the build info, e.g. with values injected through -ldflags.
+// Pass it after WithMetadata, which replaces all metadata.
+func WithAppVersion(version, commit string) Option {
+	return func(l *logger) {
+		l.metadata.AppVersion, l.metadata.GitCommit = version, commit
+	}
+}
@@ -1,25 +1,59 @@
 package logger
 
 import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
 	"time"
-
-	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
 )
 
 // logger is the implementation of the Logger interface.
-// It interacts with RabbitMQ to publish log messages to a specified queue.
+// It publishes log messages to a specified queue through a Transport (RabbitMQ by default).
 type logger struct {
-	rabbitmq         rabbitmq.RabbitMQ // RabbitMQ client for managing messages.
-	queue            string            // Name of the RabbitMQ queue where logs will be sent.
-	orderQueue       string            // Name of the RabbitMQ queue where logs will be sent.
-	bitrixOrderQueue string            // Name of the RabbitMQ queue where logs will be sent.
-	functionName     string            // Name of the function generating logs.
-	apiEndpoint      string            // API endpoint associated with the logs.
+	transport         Transport                      // Transport used to deliver messages.
+	queue             string                         // Name of the RabbitMQ queue where logs will be sent.
+	orderQueue        string                         // Name of the RabbitMQ queue where logs will be sent.
+	bitrixOrderQueue  string                         // Name of the RabbitMQ queue where logs will be sent.
+	functionName      string                         // Name of the function generating logs.
+	apiEndpoint       string                         // API endpoint associated with the logs.
+	sinks             []Sink                         // Sinks receiving a copy of every published log.
+	disabledSinks     sync.Map                       // Names of the sinks disabled at runtime, see DisableSink.
+	rawPayload        bool                           // Embed JSON payloads as nested JSON instead of a string.
+	async             *asyncQueue                    // Background publishing queue, nil unless in async mode.
+	static            *staticSegments                // Constant fields encoded once at construction.
+	encoding          Encoding                       // Wire format of the published logs.
+	avro              *avroEncoder                   // Avro encoder, nil unless set with WithAvro.
+	offload           *OffloadConfig                 // Payload offloading settings, nil unless set with WithPayloadOffload.
+	compressThreshold int                            // Size above which payloads are compressed, zero disables compression.
+	validation        *validation                    // Validation rules of the logs.
+	producerID        string                         // Random ID of the logger instance.
+	sequence          atomic.Uint64                  // Sequence number of the last accepted log.
+	defaultStatusCode int                            // Status code of logs without one, unless derived from the error code.
+	merchantKey       func(string) string            // Replaces merchant API keys before publishing, nil publishes them as is.
+	metadata          Metadata                       // Producer metadata added to every log.
+	minLevel          atomic.Int32                   // Minimal level of the published logs, changed at runtime by SetMinLevel.
+	codedErrors       bool                           // Return a *CodedError from Error and Critical.
+	translate         func(Errorcode, string) string // Fills empty client messages, nil leaves them empty.
+	stats             publishStats                   // Outcome of the deliveries, see Diagnostics.
+	report            *diagnosticsReport             // Periodic diagnostics report, nil unless set with WithDiagnosticsReport.
+	faults            *FaultConfig                   // Faults injected into publishes, nil unless set with WithFaultInjection.
+	retry             *RetryConfig                   // Retries of failed publishes, nil unless set with WithPublishRetry.
+	startupCheck      time.Duration                  // Timeout of the startup check, zero unless set with WithStartupCheck.
+	routes            *routes                        // Queues of error codes and categories, nil unless set with WithCodeRouting or WithCategoryRouting.
+	namespace         func(string) string            // Prefixes queue names, nil unless set with WithNamespace.
+	strictQueueNames  bool                           // Whether the queue names are checked at construction, see WithStrictQueueNames.
+	escalation        *escalator                     // Escalation of repeated error codes, nil unless set with WithEscalation.
 }
 
-// logRequest represents the structure of a log message sent to RabbitMQ.
+// SchemaVersion is the version of the published log schema. It is raised when fields change
+// incompatibly; added fields keep the version, consumers being expected to tolerate them.
+const SchemaVersion = 1
+
+// LogRecord is the schema of the log messages published to the log queue, built from a LogRequest.
 // It includes metadata such as error level, error messages, API endpoint, and other details.
-type logRequest struct {
+// Consumers decode messages into it as well (consumers.Record is the same type), so the two
+// sides cannot drift apart; its unexported fields are internal to the producer.
+type LogRecord struct {
 	Timestamp       time.Time `json:"timestamp"`
 	ErrorLevel      string    `json:"error_level"`
 	Errorcode       int       `json:"error_code"`
@@ -36,10 +70,71 @@ type logRequest struct {
 	EventType       string    `json:"event_type"`                 // Event type, usually based on the function name.
 	ResponseData    string    `json:"response_data,omitempty"`    // Optional response data.
 	MerchantApiKey  string    `json:"merchant_api_key,omitempty"` // Merchant API key, required if sending to merchants.
+	DurationMs      int64     `json:"duration_ms,omitempty"`      // Optional request duration in milliseconds.
+
+	RequestPayloadRef *BlobRef `json:"request_payload_ref,omitempty"` // Offloaded request payload, see WithPayloadOffload.
+	ResponseDataRef   *BlobRef `json:"response_data_ref,omitempty"`   // Offloaded response data, see WithPayloadOffload.
+
+	RequestPayloadEncoding string `json:"request_payload_encoding,omitempty"` // "gzip+base64" when the request payload is compressed, "base64" when it is binary.
+	ResponseDataEncoding   string `json:"response_data_encoding,omitempty"`   // "gzip+base64" when the response data is compressed, "base64" when it is binary.
+
+	ValidationFailed bool   `json:"validation_failed,omitempty"` // Set on logs published despite failing validation, see ValidationPolicy.PublishInvalid.
+	ValidationError  string `json:"validation_error,omitempty"`  // Validation problems of the log.
+
+	ProducerID string `json:"producer_id"` // Random ID of the logger instance, see Sequence.
+	Sequence   uint64 `json:"sequence"`    // Per-logger sequence number starting at 1, for consumers detecting gaps and reorderings.
+
+	SchemaVersion int `json:"schema_version"` // Version of the log schema, see SchemaVersion.
+
+	Host       string `json:"host,omitempty"`        // Host name of the producer, see Metadata.
+	Pod        string `json:"pod,omitempty"`         // Kubernetes pod of the producer.
+	Service    string `json:"service,omitempty"`     // Service name of the producer.
+	InstanceID string `json:"instance_id,omitempty"` // Instance ID of the producer.
+
+	Environment string `json:"environment,omitempty"` // Environment of the producer, e.g. "production".
+	AppVersion  string `json:"app_version,omitempty"` // Version of the producer.
+	GitCommit   string `json:"git_commit,omitempty"`  // Git commit the producer was built from.
+
+	UserID    string `json:"user_id,omitempty"`    // ID of the user the log is about.
+	SessionID string `json:"session_id,omitempty"` // ID of the user's session.
+	ClientIP  string `json:"client_ip,omitempty"`  // IP address of the client.
+	UserAgent string `json:"user_agent,omitempty"` // User-Agent of the client.
+	RequestID string `json:"request_id,omitempty"` // ID of the request.
+
+	Errors []ErrorDetail `json:"errors,omitempty"` // Independent causes of a joined error, see LogRequest.Err.
+
+	Extra map[string]any `json:"extra,omitempty"` // Consumer-specific metadata.
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Time after which the log may be discarded, see LogRequest.TTL.
+
+	MessageID string `json:"message_id,omitempty"` // Unique ID of the message, returned by Logger.LogWithID.
+
+	Attachments []Attachment `json:"attachments,omitempty"` // Files attached to the log, see LogRequest.Attachments.
+
+	GroupID string `json:"group_id,omitempty"` // ID shared by the logs of one logical operation, see Logger.Group.
+
+	static  *staticSegments // Pre-encoded constant fields of the logger, used by appendLogRequest.
+	payload any             // Request payload not marshaled yet, see AsyncConfig.DeferMarshal.
+	size    int64           // Estimated memory of the log, see AsyncConfig.MaxBufferedBytes.
+
+	skipSinks bool // Keeps the log from the sinks, see EscalationConfig.Alert.
+
+	budgetState uint32 // State of the log under a memory budget, accessed atomically.
+}
+
+// rawPayloadLog publishes a log with its JSON payload embedded as nested JSON.
+// Its RequestPayload field takes precedence over the one of the embedded log when encoded.
+type rawPayloadLog struct {
+	*LogRecord
+	RequestPayload json.RawMessage `json:"request_payload"`
 }
 
+// PayloadFunc builds a request payload lazily: set as LogRequest.RequestPayload, it is only
+// called for logs that are published, not for those discarded by level.
+type PayloadFunc func() any
+
 // LogRequest is a simplified structure used by the user to send log data.
-// It will be converted into a `logRequest` structure with additional metadata.
+// It will be converted into a LogRecord with additional metadata.
 type LogRequest struct {
 	Errorcode       Errorcode `json:"error_code"`
 	ClientMessageUz string    `json:"client_message_uz"`
@@ -50,10 +145,39 @@ type LogRequest struct {
 	ApiEndpoint     string    `json:"api_endpoint"`
 	Method          string    `json:"method"`
 	StatusCode      int       `json:"status_code"`
-	RequestPayload  any       `json:"request_payload"`
-	EventType       string    `json:"event_type"`                 // Event type, usually based on the function name.
+	RequestPayload  any       `json:"request_payload"`            // Request payload: a string, []byte, PayloadFunc or any value marshaled to JSON.
+	EventType       EventType `json:"event_type"`                 // Event type, see RegisterEventType.
 	ResponseData    string    `json:"response_data,omitempty"`    // Optional response data.
 	MerchantApiKey  string    `json:"merchant_api_key,omitempty"` // Merchant API key, required if sending to merchants.
+	DurationMs      int64     `json:"duration_ms,omitempty"`      // Optional request duration in milliseconds.
+	UserID          string    `json:"user_id,omitempty"`          // Optional ID of the user, see ContextWithUser.
+	SessionID       string    `json:"session_id,omitempty"`       // Optional ID of the user's session.
+	ClientIP        string    `json:"client_ip,omitempty"`        // Optional IP address of the client, see WithRequest.
+	UserAgent       string    `json:"user_agent,omitempty"`       // Optional User-Agent of the client.
+	RequestID       string    `json:"request_id,omitempty"`       // Optional ID of the request, see InjectMetadata.
+
+	// Err is the optional error behind the log. It fills ErrorMessage when empty and Errorcode
+	// when zero and the error carries a code (a Code() Errorcode method). Errors joined with
+	// errors.Join are published one by one in the `errors` field, each with its own code.
+	Err error `json:"-"`
+
+	// Extra is optional consumer-specific metadata (e.g. A/B test buckets, feature flags),
+	// published as a nested JSON object. The map must not be modified after logging.
+	Extra map[string]any `json:"extra,omitempty"`
+
+	// TTL is the optional lifetime of time-sensitive logs (e.g. transient availability warnings).
+	// It sets the `expires_at` field and the AMQP expiration of the message, so consumers and the
+	// broker can discard the log once it is no longer relevant. Zero logs never expire.
+	TTL time.Duration `json:"-"`
+
+	// Attachments are optional files (receipts, images, signed documents) published alongside
+	// the log instead of in the request payload. The slice must not be modified after logging.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// GroupID is the optional ID shared by the logs of one logical operation (e.g. an import of
+	// 10k products), so consumers can reassemble it. It is usually stamped by a logger returned
+	// by Logger.Group.
+	GroupID string `json:"group_id,omitempty"`
 }
 
 type Order struct {
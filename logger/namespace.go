@@ -0,0 +1,50 @@
+package logger
+
+import "strings"
+
+// NamespacedQueue returns the queue name prefixed with the environment and the tenant,
+// "{env}.{tenant}.{queue}", leaving out empty parts. Producers (see WithNamespace) and
+// consumers (see consumers.Config) derive their queue names with it, so brands and
+// environments sharing a RabbitMQ cluster never collide.
+func NamespacedQueue(env, tenant, queue string) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{env, tenant, queue} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// WithNamespace prefixes the log queue, the order queues and the routed queues with the environment and the
+// tenant, see NamespacedQueue. The environment is also published in the `environment` field
+// of the logs when none was detected (see DetectMetadata) or set with WithEnvironment.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", nil, nil,
+//		logger.WithNamespace("staging", "mybazar-uz"), // publishes to staging.mybazar-uz.logs
+//	)
+func WithNamespace(env, tenant string) Option {
+	return func(l *logger) {
+		l.namespace = func(queue string) string { return NamespacedQueue(env, tenant, queue) }
+		l.queue = l.namespace(l.queue)
+		if l.orderQueue != "" {
+			l.orderQueue = NamespacedQueue(env, tenant, l.orderQueue)
+		}
+		if l.bitrixOrderQueue != "" {
+			l.bitrixOrderQueue = NamespacedQueue(env, tenant, l.bitrixOrderQueue)
+		}
+		if l.routes != nil {
+			for code, queue := range l.routes.codes {
+				l.routes.codes[code] = l.namespace(queue)
+			}
+			for category, queue := range l.routes.categories {
+				l.routes.categories[category] = l.namespace(queue)
+			}
+		}
+		if env != "" && l.metadata.Environment == "" {
+			l.metadata.Environment = env
+		}
+	}
+}
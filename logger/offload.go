@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"slices"
+	"time"
+)
+
+// BlobRef points to a payload offloaded to object storage instead of being published inline.
+type BlobRef struct {
+	Bucket string `json:"bucket"` // Bucket the payload is stored in.
+	Key    string `json:"key"`    // Object key of the payload.
+	Size   int64  `json:"size"`   // Payload size in bytes.
+	SHA256 string `json:"sha256"` // Hex SHA-256 checksum of the payload.
+}
+
+// BlobStore stores payloads offloaded from log messages, e.g. in S3 or MinIO (see package s3offload).
+type BlobStore interface {
+	// Put stores the data under the key and returns the bucket it was stored in.
+	Put(ctx context.Context, key string, data []byte) (bucket string, err error)
+}
+
+// OffloadConfig holds the settings of payload offloading.
+type OffloadConfig struct {
+	Store     BlobStore     // Storage receiving the offloaded payloads.
+	Threshold int           // Size in bytes above which a payload is offloaded. Defaults to 256 KiB.
+	Prefix    string        // Key prefix of the offloaded payloads. Defaults to "payloads/".
+	Timeout   time.Duration // Timeout of a single upload. Defaults to 10s.
+
+	// OnError is called when an upload fails; the payload is then published inline. Optional.
+	OnError func(err error)
+}
+
+// WithPayloadOffload uploads request payloads and response data larger than the threshold to
+// object storage and publishes a BlobRef in `request_payload_ref`/`response_data_ref` instead,
+// keeping multi-megabyte messages off the broker. Larger attachments are offloaded the same way,
+// their reference replacing their data. Payloads are stored under
+// `<prefix><yyyy/mm/dd>/<sha256>`, so identical payloads are stored once.
+func WithPayloadOffload(config OffloadConfig) Option {
+	return func(l *logger) {
+		if config.Threshold <= 0 {
+			config.Threshold = 256 << 10
+		}
+		if config.Prefix == "" {
+			config.Prefix = "payloads/"
+		}
+		if config.Timeout <= 0 {
+			config.Timeout = 10 * time.Second
+		}
+		l.offload = &config
+	}
+}
+
+// offloadPayloads replaces the oversized payloads of the log with references.
+// A payload whose upload fails is kept inline. The encoding of an offloaded field is kept: a
+// binary payload (see sanitizePayloads) is stored base64 encoded.
+func (l *logger) offloadPayloads(log *LogRecord) {
+	if len(log.RequestPayload) > l.offload.Threshold {
+		if ref, ok := l.offloadBlob(log.Timestamp, log.RequestPayload); ok {
+			log.RequestPayload, log.RequestPayloadRef = "", ref
+		}
+	}
+	if len(log.ResponseData) > l.offload.Threshold {
+		if ref, ok := l.offloadBlob(log.Timestamp, log.ResponseData); ok {
+			log.ResponseData, log.ResponseDataRef = "", ref
+		}
+	}
+	l.offloadAttachments(log)
+}
+
+// offloadAttachments replaces the content of the oversized attachments of the log with references.
+// The attachments are copied before being changed, as the slice belongs to the caller.
+func (l *logger) offloadAttachments(log *LogRecord) {
+	copied := false
+	for i, a := range log.Attachments {
+		if len(a.Data) <= l.offload.Threshold {
+			continue
+		}
+		ref, ok := l.offloadBlob(log.Timestamp, string(a.Data))
+		if !ok {
+			continue
+		}
+		if !copied {
+			log.Attachments, copied = slices.Clone(log.Attachments), true
+		}
+		log.Attachments[i].Data, log.Attachments[i].Ref = nil, ref
+	}
+}
+
+// offloadBlob uploads a single payload.
+func (l *logger) offloadBlob(timestamp time.Time, data string) (*BlobRef, bool) {
+	sum := sha256.Sum256([]byte(data))
+	checksum := hex.EncodeToString(sum[:])
+	key := l.offload.Prefix + path.Join(timestamp.UTC().Format("2006/01/02"), checksum)
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.offload.Timeout)
+	defer cancel()
+
+	bucket, err := l.offload.Store.Put(ctx, key, []byte(data))
+	if err != nil {
+		if l.offload.OnError != nil {
+			l.offload.OnError(err)
+		}
+		return nil, false
+	}
+	return &BlobRef{Bucket: bucket, Key: key, Size: int64(len(data)), SHA256: checksum}, true
+}
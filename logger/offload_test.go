@@ -0,0 +1,78 @@
+package logger_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// memoryStore is a logger.BlobStore keeping the payloads in memory.
+type memoryStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func (s *memoryStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = append([]byte(nil), data...)
+	return "logs", nil
+}
+
+func TestOffloadWithCompressionRoundTrip(t *testing.T) {
+	store := &memoryStore{blobs: make(map[string][]byte)}
+	transport := &captureTransport{}
+	log, err := logger.NewLoggerWithTransport(transport, "logs", "Import", "/import", nil, nil,
+		logger.WithPayloadCompression(1024),
+		logger.WithPayloadOffload(logger.OffloadConfig{Store: store, Threshold: 2048}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := strings.Repeat(`{"sku":"A-1","qty":1},`, 200) // Above the offload threshold.
+	response := strings.Repeat(`{"status":"ok"},`, 100)      // Between the compression and offload thresholds.
+	err = log.Error(logger.LogRequest{
+		Errorcode:       logger.ErrInvalidData,
+		ClientMessageUz: "Xato",
+		RequestPayload:  payload,
+		ResponseData:    response,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bodies := transport.bodies()
+	if len(bodies) != 1 {
+		t.Fatalf("published %d messages, want 1", len(bodies))
+	}
+	d, err := consumers.Decode(bodies[0])
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	r := d.Record
+	if r.RequestPayloadRef == nil || r.RequestPayload != "" || r.RequestPayloadEncoding != "" {
+		t.Errorf("request payload not offloaded as is: ref %v, payload %q, encoding %q", r.RequestPayloadRef, r.RequestPayload, r.RequestPayloadEncoding)
+	} else if got := string(store.blobs[r.RequestPayloadRef.Key]); got != payload {
+		t.Errorf("stored payload = %.40q..., want the raw payload", got)
+	}
+	if r.ResponseData != response || r.ResponseDataEncoding != "" || r.ResponseDataRef != nil {
+		t.Errorf("response data not restored: %.40q..., encoding %q, ref %v", r.ResponseData, r.ResponseDataEncoding, r.ResponseDataRef)
+	}
+}
+
+func TestDecompressSkipsOffloadedFields(t *testing.T) {
+	r := logger.LogRecord{
+		RequestPayloadEncoding: logger.PayloadEncodingGzip,
+		RequestPayloadRef:      &logger.BlobRef{Bucket: "logs", Key: "payloads/x"},
+		ResponseDataEncoding:   logger.PayloadEncodingGzip,
+	}
+	if err := r.Decompress(); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package logger
+
+// Option configures optional behaviour of the logger.
+// Options are passed to `NewLogger` and `NewLoggerWithTransport`.
+type Option func(*logger)
+
+// WithRawPayload publishes JSON request payloads as nested JSON instead of a JSON-encoded string,
+// e.g. `"request_payload": {"order_id": 42}` rather than `"request_payload": "{\"order_id\": 42}"`.
+// The payload is encoded once and stays queryable in the stores; payloads that are not valid JSON
+// are still published as strings. Consumers decoding with consumers.Decode accept both forms.
+func WithRawPayload() Option {
+	return func(l *logger) {
+		l.rawPayload = true
+	}
+}
+
+// WithDefaultStatusCode sets the status code of logs without one. Defaults to 200.
+// Error and critical logs still derive it from their error code (see Errorcode.HTTPStatus)
+// when the code maps to a status.
+func WithDefaultStatusCode(code int) Option {
+	return func(l *logger) {
+		l.defaultStatusCode = code
+	}
+}
+
+// WithMinLevel discards the logs below the level. Defaults to LevelInfo, which discards debug logs.
+func WithMinLevel(level Level) Option {
+	return func(l *logger) {
+		l.minLevel.Store(int32(level))
+	}
+}
+
+// WithSinks attaches sinks that receive a copy of every published log.
+func WithSinks(sinks ...Sink) Option {
+	return func(l *logger) {
+		l.sinks = append(l.sinks, sinks...)
+	}
+}
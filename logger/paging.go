@@ -0,0 +1,190 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PagingProvider is the incident management service used by the paging sink.
+type PagingProvider string
+
+// Supported paging providers.
+const (
+	PagerDuty PagingProvider = "pagerduty"
+	Opsgenie  PagingProvider = "opsgenie"
+)
+
+// PagingConfig holds the settings of the paging sink.
+type PagingConfig struct {
+	Provider   PagingProvider `yaml:"provider"`    // Incident management service. Defaults to PagerDuty.
+	RoutingKey string         `yaml:"routing_key"` // PagerDuty Events API v2 integration key.
+	APIKey     string         `yaml:"api_key"`     // Opsgenie API key.
+	Source     string         `yaml:"source"`      // Service name reported as the incident source.
+	Codes      []Errorcode    `yaml:"codes"`       // Codes paged at any level. Defaults to ErrDatabaseError and ErrServiceUnavailable.
+	URL        string         `yaml:"url"`         // Overrides the provider API URL.
+	BufferSize int            `yaml:"buffer_size"` // Number of events buffered while waiting for delivery. Defaults to 100.
+	HTTPClient *http.Client   `yaml:"-"`           // HTTP client used for requests. Defaults to a client with a 10s timeout.
+}
+
+// pagingSink opens incidents in PagerDuty or Opsgenie for critical logs and selected codes.
+type pagingSink struct {
+	config PagingConfig // Sink settings with defaults applied.
+	worker *sinkWorker  // Delivers events in the background.
+}
+
+// NewPagingSink initializes and returns a sink opening incidents for critical logs and the configured codes.
+// Incidents are deduplicated by error code and endpoint, so repeated logs update the open incident
+// instead of paging again.
+func NewPagingSink(config PagingConfig) Sink {
+	if config.Provider == "" {
+		config.Provider = PagerDuty
+	}
+	if config.Codes == nil {
+		config.Codes = []Errorcode{ErrDatabaseError, ErrServiceUnavailable}
+	}
+	if config.URL == "" {
+		switch config.Provider {
+		case Opsgenie:
+			config.URL = "https://api.opsgenie.com/v2/alerts"
+		default:
+			config.URL = "https://events.pagerduty.com/v2/enqueue"
+		}
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 100
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	s := &pagingSink{config: config}
+	s.worker = newSinkWorker(config.BufferSize, 0, s.send)
+	return s
+}
+
+// Name returns the sink name.
+func (s *pagingSink) Name() string {
+	return "paging"
+}
+
+// Write enqueues critical logs and logs with one of the paged codes.
+func (s *pagingSink) Write(log LogRecord) error {
+	if log.ErrorLevel != LevelCritical.String() && !slices.Contains(s.config.Codes, Errorcode(log.Errorcode)) {
+		return nil
+	}
+
+	return s.worker.enqueue(log)
+}
+
+// Close sends the buffered events and stops the sink.
+func (s *pagingSink) Close() error {
+	s.worker.close()
+	return nil
+}
+
+// send delivers a single event. Delivery errors are dropped since there is nowhere to report them.
+func (s *pagingSink) send(log LogRecord) {
+	var event any
+	if s.config.Provider == Opsgenie {
+		event = s.opsgenieAlert(log)
+	} else {
+		event = s.pagerDutyEvent(log)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.Provider == Opsgenie {
+		req.Header.Set("Authorization", "GenieKey "+s.config.APIKey)
+	}
+
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// pagerDutyEvent builds a PagerDuty Events API v2 trigger event.
+func (s *pagingSink) pagerDutyEvent(log LogRecord) map[string]any {
+	severity := "error"
+	if log.ErrorLevel == LevelCritical.String() {
+		severity = "critical"
+	}
+
+	return map[string]any{
+		"routing_key":  s.config.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    pagingDedupKey(log),
+		"payload": map[string]any{
+			"summary":        truncate(pagingSummary(log), 1000),
+			"source":         s.pagingSource(log),
+			"severity":       severity,
+			"timestamp":      log.Timestamp.Format(time.RFC3339),
+			"component":      log.ApiEndpoint,
+			"class":          fmt.Sprint(log.Errorcode),
+			"custom_details": pagingDetails(log),
+		},
+	}
+}
+
+// opsgenieAlert builds an Opsgenie create-alert request.
+func (s *pagingSink) opsgenieAlert(log LogRecord) map[string]any {
+	priority := "P2"
+	if log.ErrorLevel == LevelCritical.String() {
+		priority = "P1"
+	}
+
+	return map[string]any{
+		"message":     truncate(pagingSummary(log), 130),
+		"alias":       pagingDedupKey(log),
+		"description": truncate(log.ErrorMessage, 15000),
+		"priority":    priority,
+		"source":      s.pagingSource(log),
+		"details":     pagingDetails(log),
+	}
+}
+
+// pagingSource returns the configured source, falling back to the function name.
+func (s *pagingSink) pagingSource(log LogRecord) string {
+	if s.config.Source != "" {
+		return s.config.Source
+	}
+	return log.FunctionName
+}
+
+// pagingDedupKey derives the incident key from the error code and endpoint.
+func pagingDedupKey(log LogRecord) string {
+	return fmt.Sprintf("%d:%s", log.Errorcode, log.ApiEndpoint)
+}
+
+// pagingSummary returns a one-line description of the incident.
+func pagingSummary(log LogRecord) string {
+	return fmt.Sprintf("[%s] %d %s: %s", strings.ToUpper(log.ErrorLevel), log.Errorcode, log.ApiEndpoint, log.ClientMessageUz)
+}
+
+// pagingDetails returns the log fields attached to the incident.
+func pagingDetails(log LogRecord) map[string]string {
+	return map[string]string{
+		"error_code":    fmt.Sprint(log.Errorcode),
+		"error_level":   log.ErrorLevel,
+		"error_message": truncate(log.ErrorMessage, 2000),
+		"api_endpoint":  log.ApiEndpoint,
+		"method":        log.Method,
+		"function_name": log.FunctionName,
+		"event_type":    log.EventType,
+		"status_code":   fmt.Sprint(log.StatusCode),
+	}
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrStartupCheck is returned by the constructors when the check of WithStartupCheck fails.
+var ErrStartupCheck = errors.New("logger: startup check failed")
+
+// Pinger is implemented by transports able to check their broker connection.
+type Pinger interface {
+	// Ping checks that the broker accepts messages, returning once it answered or the
+	// context is done.
+	Ping(ctx context.Context) error
+}
+
+// Ping checks the broker connection of the transport. Transports that do not implement Pinger
+// are assumed healthy.
+func (l *logger) Ping(ctx context.Context) error {
+	pinger, ok := l.transport.(Pinger)
+	if !ok {
+		return nil
+	}
+	return pinger.Ping(ctx)
+}
+
+// WithStartupCheck makes the constructor verify the broker before returning: the order queues
+// are declared along with the log queue, and the transport is pinged (see Pinger) within the
+// timeout. A misconfigured URL, missing permissions or an unreachable broker then fail the
+// service at startup with ErrStartupCheck, instead of the first real log. The timeout defaults
+// to 10s.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", &orderQueue, nil,
+//		logger.WithStartupCheck(5*time.Second),
+//	)
+func WithStartupCheck(timeout time.Duration) Option {
+	return func(l *logger) {
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+		l.startupCheck = timeout
+	}
+}
+
+// checkStartup declares the order queues and pings the transport, see WithStartupCheck.
+func (l *logger) checkStartup() error {
+	for _, queue := range []string{l.orderQueue, l.bitrixOrderQueue} {
+		if queue == "" {
+			continue
+		}
+		if err := l.transport.Declare(queue); err != nil {
+			return fmt.Errorf("%w: failed to declare queue %q: %w", ErrStartupCheck, queue, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.startupCheck)
+	defer cancel()
+	if err := l.Ping(ctx); err != nil {
+		return fmt.Errorf("%w: failed to ping broker: %w", ErrStartupCheck, err)
+	}
+	return nil
+}
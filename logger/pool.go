@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// maxPooledBufferSize is the capacity above which encode buffers are not returned to the pool,
+// so a few huge payloads do not pin memory for the lifetime of the process.
+const maxPooledBufferSize = 64 << 10
+
+// encodeBuffer is a reusable buffer with a JSON encoder writing into it.
+type encodeBuffer struct {
+	buf     bytes.Buffer
+	encoder *json.Encoder
+	msgpack *msgpack.Encoder // Created on first use.
+}
+
+// encodeBufferPool recycles the buffers messages are encoded into.
+var encodeBufferPool = sync.Pool{
+	New: func() any {
+		b := &encodeBuffer{}
+		b.encoder = json.NewEncoder(&b.buf)
+		return b
+	},
+}
+
+// logRequestPool recycles the log structs populated on every level method call.
+var logRequestPool = sync.Pool{
+	New: func() any { return new(LogRecord) },
+}
+
+// getEncodeBuffer returns an empty buffer from the pool.
+func getEncodeBuffer() *encodeBuffer {
+	b := encodeBufferPool.Get().(*encodeBuffer)
+	b.buf.Reset()
+	return b
+}
+
+// putEncodeBuffer returns the buffer to the pool. The bytes returned by encode must not be used afterwards.
+func putEncodeBuffer(b *encodeBuffer) {
+	if b.buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	encodeBufferPool.Put(b)
+}
+
+// encode encodes v as JSON, producing the same bytes as json.Marshal.
+// Logs take the reflection-free fast path of appendLogRequest.
+// The returned slice is only valid until the buffer is returned to the pool.
+func (b *encodeBuffer) encode(v any) ([]byte, error) {
+	if log, ok := v.(*LogRecord); ok {
+		if body, ok := appendLogRequest(b.buf.AvailableBuffer(), log); ok {
+			b.buf.Write(body)
+			return b.buf.Bytes(), nil
+		}
+	}
+
+	if err := b.encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	// Encode terminates the value with a newline that json.Marshal does not write.
+	return bytes.TrimSuffix(b.buf.Bytes(), []byte("\n")), nil
+}
+
+// encodeMsgPack encodes v as MessagePack, using the JSON field names and omitempty rules.
+// The returned slice is only valid until the buffer is returned to the pool.
+func (b *encodeBuffer) encodeMsgPack(v any) ([]byte, error) {
+	if b.msgpack == nil {
+		b.msgpack = msgpack.NewEncoder(&b.buf)
+		b.msgpack.SetCustomStructTag("json")
+		b.msgpack.UseCompactInts(true)
+	}
+	if err := b.msgpack.Encode(v); err != nil {
+		return nil, err
+	}
+	return b.buf.Bytes(), nil
+}
+
+// encodeProto encodes v as protobuf, see encodeProto.
+// The returned slice is only valid until the buffer is returned to the pool.
+func (b *encodeBuffer) encodeProto(v any) ([]byte, error) {
+	body, err := encodeProto(b.buf.AvailableBuffer(), v)
+	if err != nil {
+		return nil, err
+	}
+	b.buf.Write(body)
+	return b.buf.Bytes(), nil
+}
+
+// encodeAvro encodes v as a framed Avro record, see avroEncoder.
+// The returned slice is only valid until the buffer is returned to the pool.
+func (b *encodeBuffer) encodeAvro(encoder *avroEncoder, v any) ([]byte, error) {
+	body, err := encoder.encode(b.buf.AvailableBuffer(), v)
+	if err != nil {
+		return nil, err
+	}
+	b.buf.Write(body)
+	return b.buf.Bytes(), nil
+}
+
+// getLogRequest returns a log struct from the pool.
+func getLogRequest() *LogRecord {
+	return logRequestPool.Get().(*LogRecord)
+}
+
+// putLogRequest clears the log struct, so it does not keep payloads alive, and returns it to the pool.
+func putLogRequest(log *LogRecord) {
+	*log = LogRecord{}
+	logRequestPool.Put(log)
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// benchmarkLog returns a log of a typical size, as populated by the level methods.
+func benchmarkLog() *LogRecord {
+	log := &LogRecord{
+		Timestamp:       time.Now(),
+		ErrorLevel:      "error",
+		Errorcode:       int(ErrInvalidData),
+		ClientMessageUz: "Ma'lumotlar noto'g'ri",
+		ClientMessageRu: "Неверные данные",
+		ErrorMessage:    "invalid quantity for SKU A-1",
+		RequestPayload:  `{"sku":"A-1","qty":-1}`,
+		Method:          "POST",
+		StatusCode:      400,
+		MessageID:       "producer-1",
+	}
+	log.static = newStaticSegments("CreateOrder", "/api/v1/orders")
+	return log
+}
+
+func BenchmarkEncode(b *testing.B) {
+	log := benchmarkLog()
+	b.ReportAllocs()
+	for range b.N {
+		buf := getEncodeBuffer()
+		if _, err := buf.encode(log); err != nil {
+			b.Fatal(err)
+		}
+		putEncodeBuffer(buf)
+	}
+}
+
+// BenchmarkMarshal is the baseline of BenchmarkEncode.
+func BenchmarkMarshal(b *testing.B) {
+	log := benchmarkLog()
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := json.Marshal(log); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
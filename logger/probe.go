@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Probe errors, returned by the checks of ReadyCheck and LiveCheck.
+var (
+	ErrDeliveryFailing = errors.New("logger: last delivery to the broker failed")
+	ErrBufferSaturated = errors.New("logger: async buffer is saturated")
+	ErrDeliveryStalled = errors.New("logger: no delivery progress while logs are buffered")
+)
+
+// ProbeConfig holds the thresholds of the Kubernetes probe checks.
+type ProbeConfig struct {
+	// MaxBufferUsage is the fraction of the async buffer above which the logger is not ready.
+	// Defaults to 0.9.
+	MaxBufferUsage float64
+
+	// StallTimeout is how long logs may stay buffered without any successful delivery before
+	// the logger is reported dead. Defaults to 2 minutes.
+	StallTimeout time.Duration
+}
+
+// withDefaults returns the config with the defaults applied.
+func (c ProbeConfig) withDefaults() ProbeConfig {
+	if c.MaxBufferUsage <= 0 {
+		c.MaxBufferUsage = 0.9
+	}
+	if c.StallTimeout <= 0 {
+		c.StallTimeout = 2 * time.Minute
+	}
+	return c
+}
+
+// ReadyCheck returns a readiness check failing while the broker rejects deliveries (of logs and
+// orders alike) or the async buffer is saturated, so the pod stops receiving traffic it could
+// not log. It recovers on its own once deliveries succeed again.
+func ReadyCheck(l Logger, config ProbeConfig) func(ctx context.Context) error {
+	config = config.withDefaults()
+	return func(ctx context.Context) error {
+		d := l.Diagnostics()
+		if !d.Connected {
+			return fmt.Errorf("%w: %s", ErrDeliveryFailing, d.LastError)
+		}
+		if size := d.Config.AsyncBufferSize; size > 0 && float64(d.Buffered) >= config.MaxBufferUsage*float64(size) {
+			return fmt.Errorf("%w: %d of %d logs buffered", ErrBufferSaturated, d.Buffered, size)
+		}
+		return nil
+	}
+}
+
+// LiveCheck returns a liveness check failing when logs stayed buffered for longer than the
+// stall timeout without any successful delivery, i.e. when the publishing is wedged and a
+// restart is the way out. A broker outage alone fails readiness, not liveness.
+func LiveCheck(l Logger, config ProbeConfig) func(ctx context.Context) error {
+	config = config.withDefaults()
+	started := time.Now()
+	return func(ctx context.Context) error {
+		d := l.Diagnostics()
+		if d.Buffered == 0 {
+			return nil
+		}
+		last := started
+		if d.LastPublishAt != nil && d.LastPublishAt.After(last) {
+			last = *d.LastPublishAt
+		}
+		if since := time.Since(last); since > config.StallTimeout {
+			return fmt.Errorf("%w: %d logs buffered, last delivery %s ago", ErrDeliveryStalled, d.Buffered, since.Round(time.Second))
+		}
+		return nil
+	}
+}
+
+// ProbeHandler serves a check as a Kubernetes HTTP probe: 200 when it passes and 503 with
+// the error otherwise.
+//
+// Usage:
+//
+//	mux.Handle("/readyz", logger.ProbeHandler(logger.ReadyCheck(log, logger.ProbeConfig{})))
+//	mux.Handle("/livez", logger.ProbeHandler(logger.LiveCheck(log, logger.ProbeConfig{})))
+func ProbeHandler(check func(ctx context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := check(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidQueueName is returned by the constructors with WithStrictQueueNames for queue names
+// not following the naming convention, see ValidateQueueName.
+var ErrInvalidQueueName = errors.New("invalid queue name")
+
+// Queue kinds of the naming convention, see QueueName.
+const (
+	QueueKindLogs         = "logs"          // Log queue.
+	QueueKindOrders       = "orders"        // Order notification queue.
+	QueueKindBitrixOrders = "bitrix-orders" // Bitrix order queue.
+)
+
+// QueueName returns the name of a queue following the naming convention,
+// "{env}.{service}.{kind}", e.g. "production.checkout.logs". Empty parts are left out,
+// and the parts are lowercased.
+func QueueName(service, kind, env string) string {
+	return strings.ToLower(NamespacedQueue(env, service, kind))
+}
+
+// WithStrictQueueNames rejects the log, order and routed queues not following the naming
+// convention with ErrInvalidQueueName at construction, once WithNamespace is applied. Queue
+// names are not checked by default, so queues created before the convention keep working.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, logger.QueueName("checkout", logger.QueueKindLogs, env), "Checkout", "/checkout", nil, nil,
+//		logger.WithStrictQueueNames(),
+//	)
+func WithStrictQueueNames() Option {
+	return func(l *logger) {
+		l.strictQueueNames = true
+	}
+}
+
+// ValidateQueueName checks a queue name against the naming convention: dot-separated
+// non-empty segments of lowercase letters, digits, '-' and '_', at most 255 bytes (the AMQP
+// limit), and no "amq." prefix, which RabbitMQ reserves.
+func ValidateQueueName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: name is empty", ErrInvalidQueueName)
+	case len(name) > 255:
+		return fmt.Errorf("%w: %q is longer than 255 bytes", ErrInvalidQueueName, name)
+	case strings.HasPrefix(name, "amq."):
+		return fmt.Errorf("%w: %q uses the reserved amq. prefix", ErrInvalidQueueName, name)
+	}
+	for _, segment := range strings.Split(name, ".") {
+		if segment == "" {
+			return fmt.Errorf("%w: %q has an empty segment", ErrInvalidQueueName, name)
+		}
+		for _, c := range segment {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+				return fmt.Errorf("%w: %q contains %q", ErrInvalidQueueName, name, c)
+			}
+		}
+	}
+	return nil
+}
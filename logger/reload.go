@@ -0,0 +1,157 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Reloader applies changes of the configuration (see LoadConfig) to a running logger, so
+// e.g. debug logs can be turned on during an incident without restarting the service.
+// Only min_level and disabled_sinks are applied at runtime; the other settings need a restart.
+type Reloader struct {
+	logger  Logger      // Logger the changes are applied to.
+	path    string      // Config file, see LoadConfig.
+	onError func(error) // Receives the errors of background reloads, nil ignores them.
+
+	mu       sync.Mutex // Serializes reloads and protects modTime and disabled.
+	modTime  time.Time  // Modification time of the file at the last reload.
+	disabled []string   // Sinks disabled by the last reload.
+}
+
+// NewReloader initializes and returns a Reloader of the configuration at path (an empty
+// path uses $MYBAZAR_LOGGER_CONFIG_FILE, see LoadConfig). Errors of the reloads started by
+// Watch are passed to onError; an invalid file leaves the running settings unchanged.
+//
+// Usage:
+//
+//	reloader := logger.NewReloader(log, "/etc/mybazar/logger.yaml", func(err error) {
+//		fmt.Fprintln(os.Stderr, err)
+//	})
+//	go reloader.Watch(ctx, 10*time.Second)
+//	mux.Handle("POST /debug/logger/reload", reloader)
+func NewReloader(l Logger, path string, onError func(error)) *Reloader {
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	r := &Reloader{logger: l, path: path, onError: onError}
+	r.modTime, _ = r.stat()
+	if config, err := LoadConfig(path); err == nil {
+		r.disabled = config.DisabledSinks
+	}
+	return r
+}
+
+// Reload reads the configuration and applies it to the logger.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reload()
+}
+
+// reload reads and applies the configuration; the caller holds mu.
+func (r *Reloader) reload() error {
+	modTime, _ := r.stat()
+	config, err := LoadConfig(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to reload logger config: %w", err)
+	}
+	r.modTime = modTime
+
+	r.logger.SetMinLevel(config.MinLevel)
+
+	// Sinks disabled by the operator in code stay disabled unless the file listed them before.
+	var errs []error
+	for _, name := range r.disabled {
+		if !slices.Contains(config.DisabledSinks, name) {
+			_ = r.logger.EnableSink(name)
+		}
+	}
+	for _, name := range config.DisabledSinks {
+		if err := r.logger.DisableSink(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.disabled = config.DisabledSinks
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to reload logger config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Watch reloads the configuration when the file changes, checked every interval, and when
+// the process receives SIGHUP. It blocks until the context is done.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-signals:
+			r.report(r.Reload())
+		case <-ticker.C:
+			r.report(r.reloadIfChanged())
+		}
+	}
+}
+
+// reloadIfChanged reloads the configuration if the file was modified since the last reload.
+func (r *Reloader) reloadIfChanged() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTime, err := r.stat()
+	if err != nil || modTime.Equal(r.modTime) {
+		return err
+	}
+	return r.reload()
+}
+
+// stat returns the modification time of the file, zero without a file.
+func (r *Reloader) stat() (time.Time, error) {
+	if r.path == "" {
+		return time.Time{}, nil
+	}
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to check logger config: %w", err)
+	}
+	return info.ModTime(), nil
+}
+
+// report passes a reload error to onError.
+func (r *Reloader) report(err error) {
+	if err != nil && r.onError != nil {
+		r.onError(err)
+	}
+}
+
+// ServeHTTP reloads the configuration on POST requests, answering 204 on success and 500
+// with the error otherwise. Mount it behind the service's internal authentication.
+func (r *Reloader) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.Reload(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
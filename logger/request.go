@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// normalizeMethod uppercases the method, so "get", "Get" and "GET" are stored as one value.
+func normalizeMethod(method string) string {
+	return strings.ToUpper(strings.TrimSpace(method))
+}
+
+// WithRequest returns a copy of the log with Method, ApiEndpoint, ClientIP and UserAgent
+// filled from the HTTP request where they are empty. The client IP is the first address of
+// X-Forwarded-For, then X-Real-IP, then the remote address; the headers can be forged by
+// clients unless the proxy in front of the service overwrites them. Middleware with
+// WithTrustedProxies only trusts the addresses added by the proxies.
+//
+// Usage:
+//
+//	err := log.Error(logger.LogRequest{Errorcode: logger.ErrInternalServer, ...}.WithRequest(r))
+func (log LogRequest) WithRequest(r *http.Request) LogRequest {
+	if log.Method == "" {
+		log.Method = r.Method
+	}
+	if log.ApiEndpoint == "" && r.URL != nil {
+		log.ApiEndpoint = r.URL.Path
+	}
+	if log.ClientIP == "" {
+		log.ClientIP = clientIP(r)
+	}
+	if log.UserAgent == "" {
+		log.UserAgent = r.UserAgent()
+	}
+	return log
+}
+
+// clientIP returns the IP address of the client of the request.
+func clientIP(r *http.Request) string {
+	return clientAddress(r.RemoteAddr, r.Header.Values("X-Forwarded-For"), r.Header.Get("X-Real-IP"), nil)
+}
+
+// clientAddress returns the IP address of a client from the address of the peer and the
+// X-Forwarded-For and X-Real-IP values of the request.
+//
+// Without trusted proxies, the first forwarded address is used, then X-Real-IP, then the peer.
+// With trusted proxies, the headers are ignored unless the peer is a trusted proxy, and the
+// forwarded addresses are walked from the last one, appended by the nearest proxy: the first
+// address that is not a trusted proxy is the client, so clients cannot forge it.
+func clientAddress(remoteAddr string, forwardedFor []string, realIP string, trusted []netip.Prefix) string {
+	remote := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remote = host
+	}
+	var forwarded []string
+	for _, value := range forwardedFor {
+		for _, address := range strings.Split(value, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				forwarded = append(forwarded, address)
+			}
+		}
+	}
+	realIP = strings.TrimSpace(realIP)
+
+	if len(trusted) == 0 {
+		if len(forwarded) > 0 {
+			return forwarded[0]
+		}
+		if realIP != "" {
+			return realIP
+		}
+		return remote
+	}
+
+	if !isTrustedProxy(remote, trusted) {
+		return remote
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		if !isTrustedProxy(forwarded[i], trusted) {
+			return forwarded[i]
+		}
+	}
+	if len(forwarded) > 0 {
+		return forwarded[0]
+	}
+	if realIP != "" {
+		return realIP
+	}
+	return remote
+}
+
+// isTrustedProxy reports whether the address belongs to one of the trusted networks.
+// Addresses that do not parse are not trusted.
+func isTrustedProxy(address string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartTimer starts measuring a request. The returned function reports the milliseconds
+// elapsed since, for the DurationMs field.
+//
+// Usage:
+//
+//	elapsed := logger.StartTimer()
+//	resp, err := client.Do(req)
+//	if ms := elapsed(); ms > 2000 {
+//		_ = log.Warn(logger.LogRequest{Errorcode: logger.WarnHighResponseTime, DurationMs: ms, ...})
+//	}
+func StartTimer() func() int64 {
+	start := time.Now()
+	return func() int64 {
+		return time.Since(start).Milliseconds()
+	}
+}
+
+// WithDuration returns a copy of the log with DurationMs set to the duration.
+func (log LogRequest) WithDuration(d time.Duration) LogRequest {
+	log.DurationMs = d.Milliseconds()
+	return log
+}
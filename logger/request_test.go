@@ -0,0 +1,81 @@
+package logger_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+func TestMiddlewareClientIP(t *testing.T) {
+	trusted := logger.WithTrustedProxies(netip.MustParsePrefix("10.0.0.0/8"))
+	for _, tc := range []struct {
+		name      string
+		remote    string
+		forwarded []string
+		opts      []logger.MiddlewareOption
+		want      string
+	}{
+		{name: "first forwarded without trusted proxies", remote: "10.0.0.2:4000", forwarded: []string{"6.6.6.6, 1.1.1.1"}, want: "6.6.6.6"},
+		{name: "remote without forwarded", remote: "1.1.1.1:4000", opts: []logger.MiddlewareOption{trusted}, want: "1.1.1.1"},
+		{name: "last untrusted forwarded", remote: "10.0.0.2:4000", forwarded: []string{"6.6.6.6, 1.1.1.1, 10.0.0.1"}, opts: []logger.MiddlewareOption{trusted}, want: "1.1.1.1"},
+		{name: "forwarded over several headers", remote: "10.0.0.2:4000", forwarded: []string{"6.6.6.6", "1.1.1.1", "10.0.0.1"}, opts: []logger.MiddlewareOption{trusted}, want: "1.1.1.1"},
+		{name: "forwarded from an untrusted peer", remote: "8.8.8.8:4000", forwarded: []string{"1.1.1.1"}, opts: []logger.MiddlewareOption{trusted}, want: "8.8.8.8"},
+		{name: "only trusted forwarded", remote: "10.0.0.2:4000", forwarded: []string{"10.0.0.3, 10.0.0.1"}, opts: []logger.MiddlewareOption{trusted}, want: "10.0.0.3"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got logger.ContextMetadata
+			handler := logger.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = logger.MetadataFromContext(r.Context())
+			}), tc.opts...)
+
+			r := httptest.NewRequest(http.MethodPost, "/orders", nil)
+			r.RemoteAddr = tc.remote
+			r.Header.Set("User-Agent", "app/1.0")
+			for _, forwarded := range tc.forwarded {
+				r.Header.Add("X-Forwarded-For", forwarded)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got.ClientIP != tc.want {
+				t.Errorf("client IP %q, want %q", got.ClientIP, tc.want)
+			}
+			if got.UserAgent != "app/1.0" || got.ApiEndpoint != "/orders" || got.Method != http.MethodPost || got.RequestID == "" {
+				t.Errorf("unexpected metadata %+v", got)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := logger.UnaryServerInterceptor(logger.WithTrustedProxies(netip.MustParsePrefix("10.0.0.0/8")))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-forwarded-for", "6.6.6.6, 1.1.1.1",
+		"user-agent", "grpc-go/1.70.0",
+		"x-request-id", "req-1",
+	))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 4000}})
+
+	var got logger.ContextMetadata
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Create"}, func(ctx context.Context, req any) (any, error) {
+		got = logger.MetadataFromContext(ctx)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := logger.ContextMetadata{RequestID: "req-1", ApiEndpoint: "/orders.Orders/Create", ClientIP: "1.1.1.1", UserAgent: "grpc-go/1.70.0"}
+	if got != want {
+		t.Errorf("metadata %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package logger
+
+import "time"
+
+// RetryConfig holds the settings of publish retries, see WithPublishRetry.
+type RetryConfig struct {
+	MaxRetries int           // Retries after a failed publish. Defaults to 3.
+	Backoff    time.Duration // Wait before the first retry, doubled before each next one. Defaults to 100ms.
+}
+
+// WithPublishRetry publishes messages again when the transport fails to deliver them, waiting
+// with exponential backoff between the attempts. Retries are counted in Stats.Retried; a message
+// still failing after the last retry is counted as failed. In sync mode the retries add to the
+// latency of the level methods, so keep MaxRetries and Backoff small or combine it with WithAsync.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", nil, nil,
+//		logger.WithAsync(logger.AsyncConfig{}),
+//		logger.WithPublishRetry(logger.RetryConfig{MaxRetries: 5, Backoff: 200 * time.Millisecond}),
+//	)
+func WithPublishRetry(config RetryConfig) Option {
+	return func(l *logger) {
+		if config.MaxRetries <= 0 {
+			config.MaxRetries = 3
+		}
+		if config.Backoff <= 0 {
+			config.Backoff = 100 * time.Millisecond
+		}
+		l.retry = &config
+	}
+}
+
+// withRetry calls publish, which delivers n messages, until it succeeds or the retries run out.
+func (l *logger) withRetry(n int, publish func() error) error {
+	err := publish()
+	if l.retry == nil {
+		return err
+	}
+	backoff := l.retry.Backoff
+	for attempt := 0; err != nil && attempt < l.retry.MaxRetries; attempt++ {
+		time.Sleep(backoff)
+		backoff *= 2
+		l.stats.retried.Add(uint64(n))
+		err = publish()
+	}
+	return err
+}
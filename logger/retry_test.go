@@ -0,0 +1,56 @@
+package logger_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// flakyTransport fails the given number of publishes before accepting the next ones.
+type flakyTransport struct {
+	captureTransport
+	mu       sync.Mutex
+	failures int
+}
+
+func (t *flakyTransport) Publish(msg logger.Message) error {
+	t.mu.Lock()
+	if t.failures > 0 {
+		t.failures--
+		t.mu.Unlock()
+		return errors.New("broker down")
+	}
+	t.mu.Unlock()
+	return t.captureTransport.Publish(msg)
+}
+
+func TestPublishRetry(t *testing.T) {
+	retry := logger.WithPublishRetry(logger.RetryConfig{MaxRetries: 3, Backoff: time.Millisecond})
+	for _, tc := range []struct {
+		name     string
+		failures int
+		want     logger.Stats
+	}{
+		{name: "recovered", failures: 2, want: logger.Stats{Published: 1, Retried: 2}},
+		{name: "exhausted", failures: 10, want: logger.Stats{Failed: 1, Retried: 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &flakyTransport{failures: tc.failures}
+			log, err := logger.NewLoggerWithTransport(transport, "logs", "SyncStock", "/internal/stock", nil, nil, retry)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = info(log, "synced")
+			if (err != nil) != (tc.want.Failed > 0) {
+				t.Errorf("Info returned %v", err)
+			}
+			if got := log.Stats(); got != tc.want {
+				t.Errorf("stats %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
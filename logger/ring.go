@@ -0,0 +1,93 @@
+package logger
+
+import "sync/atomic"
+
+// cacheLinePad keeps the hot counters of the ring on separate cache lines, so producers
+// advancing the tail do not invalidate the line the consumer reads the head from.
+type cacheLinePad [64]byte
+
+// ring is a bounded lock-free queue of logs (Dmitry Vyukov's sequence-numbered slots).
+// Any number of goroutines may push concurrently; pops are safe from several goroutines as
+// well, which lets producers evict the oldest log under the DropOldest policy.
+type ring struct {
+	_     cacheLinePad
+	head  atomic.Uint64 // Position of the next pop.
+	_     cacheLinePad
+	tail  atomic.Uint64 // Position of the next push.
+	_     cacheLinePad
+	mask  uint64     // Capacity minus one; the capacity is a power of two.
+	slots []ringSlot // Slots indexed by position & mask.
+}
+
+// ringSlot holds one log. Its sequence tells whether it is free for the push at position seq
+// or holds the log pushed at position seq-1.
+type ringSlot struct {
+	seq atomic.Uint64
+	log *LogRecord
+}
+
+// newRing returns an empty ring holding at least size logs.
+func newRing(size int) *ring {
+	capacity := 1
+	for capacity < size {
+		capacity <<= 1
+	}
+
+	r := &ring{mask: uint64(capacity - 1), slots: make([]ringSlot, capacity)}
+	for i := range r.slots {
+		r.slots[i].seq.Store(uint64(i))
+	}
+	return r
+}
+
+// push adds the log, reporting false when the ring is full.
+func (r *ring) push(log *LogRecord) bool {
+	pos := r.tail.Load()
+	for {
+		slot := &r.slots[pos&r.mask]
+		switch diff := int64(slot.seq.Load() - pos); {
+		case diff == 0:
+			if r.tail.CompareAndSwap(pos, pos+1) {
+				slot.log = log
+				slot.seq.Store(pos + 1)
+				return true
+			}
+			pos = r.tail.Load()
+		case diff < 0:
+			return false
+		default:
+			pos = r.tail.Load()
+		}
+	}
+}
+
+// pop removes the oldest log, reporting false when the ring is empty.
+func (r *ring) pop() (*LogRecord, bool) {
+	pos := r.head.Load()
+	for {
+		slot := &r.slots[pos&r.mask]
+		switch diff := int64(slot.seq.Load() - (pos + 1)); {
+		case diff == 0:
+			if r.head.CompareAndSwap(pos, pos+1) {
+				log := slot.log
+				slot.log = nil
+				slot.seq.Store(pos + r.mask + 1)
+				return log, true
+			}
+			pos = r.head.Load()
+		case diff < 0:
+			return nil, false
+		default:
+			pos = r.head.Load()
+		}
+	}
+}
+
+// len returns the approximate number of logs in the ring.
+func (r *ring) len() int {
+	n := int64(r.tail.Load() - r.head.Load())
+	if n < 0 {
+		return 0
+	}
+	return int(n)
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestRingConcurrentProducers(t *testing.T) {
+	const producers, perProducer = 8, 2000
+	r := newRing(64)
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				log := &LogRecord{Sequence: uint64(p*perProducer + i)}
+				for !r.push(log) {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	seen := make([]bool, producers*perProducer)
+	last := make([]int, producers)
+	for i := range last {
+		last[i] = -1
+	}
+	for n := 0; n < len(seen); {
+		log, ok := r.pop()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if seen[log.Sequence] {
+			t.Fatalf("log %d popped twice", log.Sequence)
+		}
+		seen[log.Sequence] = true
+		// The logs of a producer come out in the order it pushed them.
+		p, i := int(log.Sequence)/perProducer, int(log.Sequence)%perProducer
+		if i <= last[p] {
+			t.Fatalf("producer %d: log %d popped after log %d", p, i, last[p])
+		}
+		last[p] = i
+		n++
+	}
+	wg.Wait()
+
+	if _, ok := r.pop(); ok {
+		t.Error("ring not empty after popping every log")
+	}
+}
+
+func TestRingFull(t *testing.T) {
+	r := newRing(3) // Rounded up to 4.
+	for i := range 4 {
+		if !r.push(&LogRecord{Sequence: uint64(i)}) {
+			t.Fatalf("push %d failed on a ring with room", i)
+		}
+	}
+	if r.push(&LogRecord{}) {
+		t.Error("push succeeded on a full ring")
+	}
+	if n := r.len(); n != 4 {
+		t.Errorf("len() = %d, want 4", n)
+	}
+	for i := range 4 {
+		log, ok := r.pop()
+		if !ok || log.Sequence != uint64(i) {
+			t.Fatalf("pop %d = %v, %v", i, log, ok)
+		}
+	}
+	if _, ok := r.pop(); ok {
+		t.Error("pop succeeded on an empty ring")
+	}
+}
+
+func BenchmarkRing(b *testing.B) {
+	r := newRing(8192)
+	log := &LogRecord{}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r.push(log)
+			r.pop()
+		}
+	})
+}
+
+func BenchmarkChannel(b *testing.B) {
+	ch := make(chan *LogRecord, 8192)
+	log := &LogRecord{}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- log
+			<-ch
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package logger
+
+import "slices"
+
+// routes maps error codes and categories to the queues their logs are published to.
+type routes struct {
+	codes      map[Errorcode]string // Queues of the error codes.
+	categories map[Category]string  // Queues of the categories, used for codes without a queue.
+}
+
+// WithCodeRouting publishes the logs of the error codes to dedicated queues instead of the log
+// queue, e.g. payment errors to a queue the finance team consumes. Logs of other codes stay on
+// the log queue. The queues are validated and declared when the logger is created and, like
+// the log queue, prefixed by WithNamespace whatever the order of the options. Routes of codes
+// take precedence over those of WithCategoryRouting.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "CreatePayment", "/api/v1/payments", nil, nil,
+//		logger.WithCodeRouting(map[logger.Errorcode]string{
+//			logger.ErrPaymentRejected: "finance-logs",
+//			logger.ErrRefundFailed:    "finance-logs",
+//		}),
+//	)
+func WithCodeRouting(queues map[Errorcode]string) Option {
+	return func(l *logger) {
+		r := l.routing()
+		for code, queue := range queues {
+			r.codes[code] = l.namespaced(queue)
+		}
+	}
+}
+
+// WithCategoryRouting publishes the logs of the error code categories to dedicated queues
+// instead of the log queue, e.g. CategoryIntegration to the queue of the integrations team.
+// It works like WithCodeRouting, whose routes take precedence.
+func WithCategoryRouting(queues map[Category]string) Option {
+	return func(l *logger) {
+		r := l.routing()
+		for category, queue := range queues {
+			r.categories[category] = l.namespaced(queue)
+		}
+	}
+}
+
+// routing returns the routes of the logger, creating them on first use.
+func (l *logger) routing() *routes {
+	if l.routes == nil {
+		l.routes = &routes{codes: make(map[Errorcode]string), categories: make(map[Category]string)}
+	}
+	return l.routes
+}
+
+// namespaced returns the queue prefixed with the namespace set with WithNamespace, if any.
+func (l *logger) namespaced(queue string) string {
+	if l.namespace == nil {
+		return queue
+	}
+	return l.namespace(queue)
+}
+
+// destination returns the queue of the log: the queue routed for its error code or its
+// category, or the log queue.
+func (l *logger) destination(log *LogRecord) string {
+	if l.routes == nil || log.Errorcode == 0 {
+		return l.queue
+	}
+	code := Errorcode(log.Errorcode)
+	if queue, ok := l.routes.codes[code]; ok {
+		return queue
+	}
+	if queue, ok := l.routes.categories[code.Category()]; ok {
+		return queue
+	}
+	return l.queue
+}
+
+// routedQueues returns the distinct queues of the routes other than the log queue, sorted.
+func (l *logger) routedQueues() []string {
+	if l.routes == nil {
+		return nil
+	}
+	var queues []string
+	for _, queue := range l.routes.codes {
+		queues = append(queues, queue)
+	}
+	for _, queue := range l.routes.categories {
+		queues = append(queues, queue)
+	}
+	slices.Sort(queues)
+	queues = slices.Compact(queues)
+	return slices.DeleteFunc(queues, func(queue string) bool { return queue == l.queue })
+}
@@ -0,0 +1,209 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleMatch selects the logs counted by a rule. Empty fields match everything.
+type RuleMatch struct {
+	Codes      []Errorcode `yaml:"codes"`      // Error codes counted by the rule.
+	Categories []Category  `yaml:"categories"` // Error code categories counted by the rule.
+	Levels     []Level     `yaml:"levels"`     // Levels counted by the rule.
+	Endpoints  []string    `yaml:"endpoints"`  // API endpoints counted by the rule.
+}
+
+// Rule fires an alert when more than Threshold matching logs are seen within Window.
+//
+// Example (YAML):
+//
+//	rules:
+//	  - name: database-down
+//	    match:
+//	      codes: [4003]
+//	    threshold: 20
+//	    window: 5m
+//	    cooldown: 15m
+//	    severity: critical
+//	    sinks: [telegram, paging]
+type Rule struct {
+	Name      string        `yaml:"name"`      // Unique name of the rule, shown in alerts.
+	Match     RuleMatch     `yaml:"match"`     // Logs counted by the rule.
+	Threshold int           `yaml:"threshold"` // The rule fires when the count exceeds the threshold.
+	Window    time.Duration `yaml:"window"`    // Sliding window the logs are counted in.
+	Cooldown  time.Duration `yaml:"cooldown"`  // Minimal time between two alerts of the rule. Defaults to the window.
+	Severity  Level         `yaml:"severity"`  // Level of the alert log sent to the sinks.
+	Sinks     []string      `yaml:"sinks"`     // Names of the sinks receiving the alert.
+}
+
+// rulesFile is the layout of a rules YAML file.
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadRules reads alert rules from a YAML file.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses alert rules from YAML and validates them.
+func ParseRules(data []byte) ([]Rule, error) {
+	var file rulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for i, rule := range file.Rules {
+		switch {
+		case rule.Name == "":
+			return nil, fmt.Errorf("rule %d: name is required", i+1)
+		case names[rule.Name]:
+			return nil, fmt.Errorf("rule %q: duplicate name", rule.Name)
+		case rule.Window <= 0:
+			return nil, fmt.Errorf("rule %q: window must be positive", rule.Name)
+		case len(rule.Sinks) == 0:
+			return nil, fmt.Errorf("rule %q: at least one sink is required", rule.Name)
+		}
+		names[rule.Name] = true
+	}
+	return file.Rules, nil
+}
+
+// RulesEngine evaluates alert rules over the log stream and dispatches alerts to sinks.
+type RulesEngine struct {
+	rules []*ruleState    // Rules with their counting state.
+	sinks map[string]Sink // Sinks by name.
+}
+
+// ruleState is a rule with the timestamps of the logs it counted.
+type ruleState struct {
+	Rule
+	mu        sync.Mutex  // Protects seen and lastFired.
+	seen      []time.Time // Timestamps of matching logs within the window.
+	lastFired time.Time   // Time of the last alert.
+}
+
+// NewRulesEngine initializes and returns a rules engine dispatching alerts to the given sinks.
+// Sinks are referenced from rules by their Name. It returns an error if a rule references an unknown sink.
+func NewRulesEngine(rules []Rule, sinks ...Sink) (*RulesEngine, error) {
+	e := &RulesEngine{sinks: make(map[string]Sink, len(sinks))}
+	for _, sink := range sinks {
+		e.sinks[sink.Name()] = sink
+	}
+
+	for _, rule := range rules {
+		for _, name := range rule.Sinks {
+			if _, ok := e.sinks[name]; !ok {
+				return nil, fmt.Errorf("rule %q: unknown sink %q", rule.Name, name)
+			}
+		}
+		if rule.Cooldown <= 0 {
+			rule.Cooldown = rule.Window
+		}
+		e.rules = append(e.rules, &ruleState{Rule: rule})
+	}
+	return e, nil
+}
+
+// ObserveMessage decodes a published log message and evaluates the rules against it.
+func (e *RulesEngine) ObserveMessage(body []byte) error {
+	var log LogRecord
+	if err := json.Unmarshal(body, &log); err != nil {
+		return fmt.Errorf("failed to decode log: %w", err)
+	}
+	return e.observe(log)
+}
+
+// observe counts the log for every matching rule and fires the rules whose threshold is exceeded.
+func (e *RulesEngine) observe(log LogRecord) error {
+	var errs []error
+	for _, rule := range e.rules {
+		if !rule.Match.match(log) {
+			continue
+		}
+		if count, fire := rule.count(log.Timestamp); fire {
+			errs = append(errs, e.fire(rule, log, count))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fire sends the alert of the rule to its sinks.
+func (e *RulesEngine) fire(rule *ruleState, trigger LogRecord, count int) error {
+	alert := LogRecord{
+		Timestamp:       time.Now(),
+		ErrorLevel:      rule.Severity.String(),
+		Errorcode:       trigger.Errorcode,
+		ClientMessageUz: fmt.Sprintf("Ogohlantirish qoidasi ishga tushdi: %s", rule.Name),
+		ClientMessageRu: fmt.Sprintf("Сработало правило оповещения: %s", rule.Name),
+		ErrorMessage:    fmt.Sprintf("rule %q: %d matching logs in %s (threshold %d), last: %s", rule.Name, count, rule.Window, rule.Threshold, trigger.ErrorMessage),
+		ApiEndpoint:     trigger.ApiEndpoint,
+		Method:          trigger.Method,
+		FunctionName:    trigger.FunctionName,
+		StatusCode:      trigger.StatusCode,
+		EventType:       string(EventAlertRule),
+	}
+
+	var errs []error
+	for _, name := range rule.Sinks {
+		if err := e.sinks[name].Write(alert); err != nil {
+			errs = append(errs, fmt.Errorf("rule %q: sink %q: %w", rule.Name, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// count records a matching log and reports whether the rule should fire.
+func (r *ruleState) count(timestamp time.Time) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seen = append(r.seen, timestamp)
+	cutoff := timestamp.Add(-r.Window)
+	kept := r.seen[:0]
+	for _, t := range r.seen {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	r.seen = kept
+
+	if len(r.seen) <= r.Threshold || timestamp.Sub(r.lastFired) < r.Cooldown {
+		return len(r.seen), false
+	}
+	r.lastFired = timestamp
+	return len(r.seen), true
+}
+
+// match reports whether the log is counted by the rule.
+func (m RuleMatch) match(log LogRecord) bool {
+	code := Errorcode(log.Errorcode)
+	if len(m.Codes) > 0 && !slices.Contains(m.Codes, code) {
+		return false
+	}
+	if len(m.Categories) > 0 && !slices.Contains(m.Categories, code.Category()) {
+		return false
+	}
+	if len(m.Levels) > 0 {
+		level, err := ParseLevel(log.ErrorLevel)
+		if err != nil || !slices.Contains(m.Levels, level) {
+			return false
+		}
+	}
+	if len(m.Endpoints) > 0 && !slices.Contains(m.Endpoints, log.ApiEndpoint) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"encoding/base64"
+	"strings"
+	"unicode/utf8"
+)
+
+// PayloadEncodingBase64 flags a field holding the base64 encoding of a binary value, see
+// sanitizePayloads. consumers.Decode keeps such fields encoded.
+const PayloadEncodingBase64 = "base64"
+
+// sanitizePayloads base64-encodes binary request payloads and response data, flagging them with
+// `request_payload_encoding`/`response_data_encoding` set to "base64". Binary bodies (images,
+// protobuf, gzip) would otherwise be mangled into U+FFFD by JSON, rejected by the protobuf
+// encoding, or break stores refusing NUL characters. Invalid UTF-8 in the message fields is
+// replaced with U+FFFD.
+func sanitizePayloads(log *LogRecord) {
+	if log.RequestPayloadEncoding == "" && isBinary(log.RequestPayload) {
+		log.RequestPayload = base64.StdEncoding.EncodeToString([]byte(log.RequestPayload))
+		log.RequestPayloadEncoding = PayloadEncodingBase64
+	}
+	if log.ResponseDataEncoding == "" && isBinary(log.ResponseData) {
+		log.ResponseData = base64.StdEncoding.EncodeToString([]byte(log.ResponseData))
+		log.ResponseDataEncoding = PayloadEncodingBase64
+	}
+
+	for _, field := range []*string{&log.ErrorMessage, &log.ClientMessageUz, &log.ClientMessageRu, &log.DetailsUz, &log.DetailsRu} {
+		if !utf8.ValidString(*field) {
+			*field = strings.ToValidUTF8(*field, "�")
+		}
+	}
+}
+
+// isBinary reports whether the value is not text: invalid UTF-8, or containing control
+// characters other than tabs and line breaks.
+func isBinary(value string) bool {
+	for i := 0; i < len(value); {
+		c := value[i]
+		if c < utf8.RuneSelf {
+			if c < 0x20 && c != '\t' && c != '\n' && c != '\r' {
+				return true
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(value[i:])
+		if r == utf8.RuneError && size == 1 {
+			return true
+		}
+		i += size
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
+)
+
+// ShutdownTimeout is the deadline of the shutdown started by RunUntilSignal.
+const ShutdownTimeout = 15 * time.Second
+
+// StopFunc stops a component producing logs (an HTTP server, a consumer) before the logger is
+// closed, returning once it stopped or the context is done.
+type StopFunc func(ctx context.Context) error
+
+// RunUntilSignal blocks until the process receives SIGINT or SIGTERM, then shuts down within
+// ShutdownTimeout, see Shutdown. It returns the errors of the shutdown.
+//
+// Usage:
+//
+//	server := &http.Server{Addr: ":8080", Handler: mux}
+//	go server.ListenAndServe()
+//	consumerCtx, stopConsumer := context.WithCancel(context.Background())
+//	go consumer.Run(consumerCtx, handler)
+//
+//	err := logger.RunUntilSignal(log, rabbitMQ, server.Shutdown, func(context.Context) error {
+//		stopConsumer()
+//		return nil
+//	})
+func RunUntilSignal(l Logger, r rabbitmq.RabbitMQ, stops ...StopFunc) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	<-ctx.Done()
+	stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	return Shutdown(ctx, l, r, stops...)
+}
+
+// Shutdown stops the service in the order that keeps its last logs: the components producing
+// logs first (in the given order), then the logger, publishing the logs buffered in async mode
+// and closing the sinks, and the RabbitMQ connection last. Every step runs even if a previous
+// one failed; steps still running when the context is done are abandoned. r may be nil, e.g.
+// for loggers publishing to Kafka.
+func Shutdown(ctx context.Context, l Logger, r rabbitmq.RabbitMQ, stops ...StopFunc) error {
+	var errs []error
+	for i, stop := range stops {
+		if err := stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop component %d: %w", i+1, err))
+		}
+	}
+	if err := l.Close(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close logger: %w", err))
+	}
+	if r != nil {
+		if err := r.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close rabbitmq connection: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
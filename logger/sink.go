@@ -0,0 +1,197 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrUnknownSink is returned by EnableSink and DisableSink for names of no attached sink.
+var ErrUnknownSink = errors.New("unknown sink")
+
+// ErrSinkBufferFull is returned by a sink when its delivery buffer is full and the log was dropped.
+var ErrSinkBufferFull = errors.New("sink buffer is full, log dropped")
+
+// ErrSinkClosed is returned by a network sink receiving a log after it was closed.
+var ErrSinkClosed = errors.New("sink is closed")
+
+// Sink receives a copy of every log published by the logger, e.g. to forward alerts to chat tools.
+// Sinks filter the logs they are interested in themselves. Errors returned by a sink never fail
+// the logging call.
+type Sink interface {
+	// Name returns the unique name of the sink.
+	Name() string
+
+	// Write hands a log to the sink. Network sinks only enqueue the log and deliver it in the background.
+	Write(log LogRecord) error
+
+	// Close delivers the buffered logs and releases the sink resources.
+	Close() error
+}
+
+// writeSinks hands the log to all attached sinks that are not disabled, unless it skips them.
+func (l *logger) writeSinks(log LogRecord) {
+	if log.skipSinks {
+		return
+	}
+	for _, sink := range l.sinks {
+		if _, disabled := l.disabledSinks.Load(sink.Name()); disabled {
+			continue
+		}
+		_ = sink.Write(log)
+	}
+}
+
+// EnableSink resumes handing logs to the sink with the name.
+func (l *logger) EnableSink(name string) error {
+	if !l.hasSink(name) {
+		return fmt.Errorf("%w: %q", ErrUnknownSink, name)
+	}
+	l.disabledSinks.Delete(name)
+	return nil
+}
+
+// DisableSink stops handing logs to the sink with the name until it is enabled again.
+// Logs are still published to the broker and to the other sinks.
+func (l *logger) DisableSink(name string) error {
+	if !l.hasSink(name) {
+		return fmt.Errorf("%w: %q", ErrUnknownSink, name)
+	}
+	l.disabledSinks.Store(name, struct{}{})
+	return nil
+}
+
+// SinkEnabled reports whether the sink with the name receives logs.
+func (l *logger) SinkEnabled(name string) bool {
+	_, disabled := l.disabledSinks.Load(name)
+	return l.hasSink(name) && !disabled
+}
+
+// hasSink reports whether a sink with the name is attached.
+func (l *logger) hasSink(name string) bool {
+	for _, sink := range l.sinks {
+		if sink.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sinkWorker delivers logs of a network sink in the background, so that a slow
+// destination never blocks the logging call.
+type sinkWorker struct {
+	queue    chan LogRecord  // Buffered logs waiting for delivery.
+	deliver  func(LogRecord) // Function delivering a single log.
+	interval time.Duration   // Minimal interval between two deliveries, used for rate limiting.
+	wg       sync.WaitGroup  // Tracks the running delivery goroutines.
+	mu       sync.RWMutex    // Protects closed; held for reading while sending to the queue.
+	closed   bool            // Set once the queue is closed.
+}
+
+// newSinkWorker starts a worker with the given buffer size.
+// A non-zero interval limits the delivery rate to one log per interval.
+func newSinkWorker(bufferSize int, interval time.Duration, deliver func(LogRecord)) *sinkWorker {
+	w := &sinkWorker{
+		queue:    make(chan LogRecord, bufferSize),
+		deliver:  deliver,
+		interval: interval,
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w
+}
+
+// newSinkWorkerPool starts a worker delivering up to concurrency logs in parallel, without rate limiting.
+func newSinkWorkerPool(bufferSize, concurrency int, deliver func(LogRecord)) *sinkWorker {
+	w := &sinkWorker{
+		queue:   make(chan LogRecord, bufferSize),
+		deliver: deliver,
+	}
+	for i := 0; i < concurrency; i++ {
+		w.wg.Add(1)
+		go w.run()
+	}
+	return w
+}
+
+// enqueue adds the log to the delivery queue without blocking. It returns ErrSinkClosed
+// once the worker is closed.
+func (w *sinkWorker) enqueue(log LogRecord) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return ErrSinkClosed
+	}
+	select {
+	case w.queue <- log:
+		return nil
+	default:
+		return ErrSinkBufferFull
+	}
+}
+
+// close stops accepting logs and waits until the buffered ones are delivered.
+func (w *sinkWorker) close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+}
+
+// run delivers queued logs until the queue is closed.
+func (w *sinkWorker) run() {
+	defer w.wg.Done()
+
+	var last time.Time
+	for log := range w.queue {
+		if wait := w.interval - time.Since(last); w.interval > 0 && wait > 0 {
+			time.Sleep(wait)
+		}
+		last = time.Now()
+		w.deliver(log)
+	}
+}
+
+// deduplicator suppresses repeated alerts with the same key within a time window.
+type deduplicator struct {
+	mu     sync.Mutex           // Protects seen.
+	window time.Duration        // Time window in which repeated keys are suppressed.
+	seen   map[string]time.Time // Time each key was last let through.
+}
+
+// newDeduplicator returns a deduplicator with the given window. A zero window disables deduplication.
+func newDeduplicator(window time.Duration) *deduplicator {
+	return &deduplicator{
+		window: window,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// allow reports whether an alert with the key should be sent now.
+func (d *deduplicator) allow(key string) bool {
+	if d.window <= 0 {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if last, ok := d.seen[key]; ok && now.Sub(last) < d.window {
+		return false
+	}
+
+	// Forget expired keys so the map does not grow without bound.
+	for k, last := range d.seen {
+		if now.Sub(last) >= d.window {
+			delete(d.seen, k)
+		}
+	}
+
+	d.seen[key] = now
+	return true
+}
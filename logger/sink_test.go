@@ -0,0 +1,178 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// recordSink records the logs it receives and counts those received after Close.
+type recordSink struct {
+	mu          sync.Mutex
+	logs        []logger.LogRecord
+	lateWrites  int
+	closed      bool
+	closedEvent chan struct{} // Closed by Close.
+}
+
+func newRecordSink() *recordSink {
+	return &recordSink{closedEvent: make(chan struct{})}
+}
+
+func (s *recordSink) Name() string { return "record" }
+
+func (s *recordSink) Write(log logger.LogRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		s.lateWrites++
+		return logger.ErrSinkClosed
+	}
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func (s *recordSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.closedEvent)
+	}
+	return nil
+}
+
+func TestNetworkSinkWriteAfterClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	webhook, err := logger.NewWebhookSink(logger.WebhookConfig{
+		Destinations: []logger.WebhookDestination{{Name: "alerts", URL: server.URL}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sinks := []logger.Sink{
+		webhook,
+		logger.NewTelegramSink(logger.TelegramConfig{BotToken: "token", ChatID: "1", APIURL: server.URL}),
+		logger.NewSlackSink(logger.SlackConfig{Routes: []logger.SlackRoute{{WebhookURL: server.URL}}}),
+		logger.NewPagingSink(logger.PagingConfig{RoutingKey: "key"}),
+	}
+
+	log := logger.LogRecord{ErrorLevel: "critical", Errorcode: int(logger.ErrDatabaseError), ErrorMessage: "down"}
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Write(log); !errors.Is(err, logger.ErrSinkClosed) {
+			t.Errorf("%s: Write after Close returned %v, want ErrSinkClosed", sink.Name(), err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Errorf("%s: second Close: %v", sink.Name(), err)
+		}
+	}
+}
+
+func TestLogAfterClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	webhook, err := logger.NewWebhookSink(logger.WebhookConfig{
+		Destinations: []logger.WebhookDestination{{Name: "alerts", URL: server.URL}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := logger.NewLoggerWithTransport(&captureTransport{}, "logs", "Import", "/import", nil, nil, logger.WithSinks(webhook))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The sink errors are not reported to the caller; logging must just not panic.
+	if err := info(log, "late"); err != nil {
+		t.Errorf("log after Close: %v", err)
+	}
+}
+
+func TestCloseExpiredContext(t *testing.T) {
+	transport := newGateTransport()
+	sink := newRecordSink()
+	log, err := logger.NewLoggerWithTransport(transport, "logs", "Import", "/import", nil, nil,
+		logger.WithAsync(logger.AsyncConfig{}),
+		logger.WithSinks(sink),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, message := range []string{"1", "2"} {
+		if err := info(log, message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-transport.started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := log.Close(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Close returned %v, want context.Canceled", err)
+	}
+	select {
+	case <-sink.closedEvent:
+		t.Fatal("sink closed while logs were still being published")
+	default:
+	}
+
+	// The publisher finishes in the background, then closes the sinks.
+	close(transport.gate)
+	select {
+	case <-sink.closedEvent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sink not closed after the logs were published")
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if len(sink.logs) != 2 || sink.lateWrites != 0 {
+		t.Errorf("sink received %d logs and %d after Close, want 2 and 0", len(sink.logs), sink.lateWrites)
+	}
+}
+
+func TestTelegramSinkMinLevel(t *testing.T) {
+	levelInfo := logger.LevelInfo
+	for _, tc := range []struct {
+		name     string
+		minLevel *logger.Level
+		want     int32
+	}{
+		{name: "default", minLevel: nil, want: 1},
+		{name: "info", minLevel: &levelInfo, want: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { sent.Add(1) }))
+			defer server.Close()
+
+			sink := logger.NewTelegramSink(logger.TelegramConfig{BotToken: "token", ChatID: "1", APIURL: server.URL, MinLevel: tc.minLevel, RateLimit: 6000})
+			for _, level := range []string{"info", "critical"} {
+				if err := sink.Write(logger.LogRecord{ErrorLevel: level, ErrorMessage: level}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := sent.Load(); got != tc.want {
+				t.Errorf("sent %d alerts, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SlackRoute sends the logs matching its filters to one Slack incoming webhook (i.e. one channel).
+type SlackRoute struct {
+	WebhookURL string     `yaml:"webhook_url"` // Incoming webhook URL of the channel.
+	Categories []Category `yaml:"categories"`  // Error code categories routed to the channel; empty matches all categories.
+	MinLevel   Level      `yaml:"min_level"`   // Minimal level routed to the channel.
+}
+
+// SlackConfig holds the settings of the Slack webhook sink.
+type SlackConfig struct {
+	Routes      []SlackRoute  `yaml:"routes"`       // Routes checked in order; a log is sent to the first matching route only.
+	DedupWindow time.Duration `yaml:"dedup_window"` // Identical messages (same code, endpoint and message) are sent once per window. Defaults to 5 minutes.
+	BufferSize  int           `yaml:"buffer_size"`  // Number of messages buffered while waiting for delivery. Defaults to 100.
+	HTTPClient  *http.Client  `yaml:"-"`            // HTTP client used for requests. Defaults to a client with a 10s timeout.
+}
+
+// slackSink posts block-kit formatted logs to Slack incoming webhooks.
+type slackSink struct {
+	config SlackConfig   // Sink settings with defaults applied.
+	dedup  *deduplicator // Suppresses repeated messages.
+	worker *sinkWorker   // Delivers messages in the background.
+}
+
+// NewSlackSink initializes and returns a sink posting logs to Slack channels.
+//
+// Usage:
+//
+//	sink := logger.NewSlackSink(logger.SlackConfig{
+//		Routes: []logger.SlackRoute{
+//			{WebhookURL: paymentsHook, Categories: []logger.Category{logger.CategoryBusiness}, MinLevel: logger.LevelError},
+//			{WebhookURL: opsHook, MinLevel: logger.LevelCritical},
+//		},
+//	})
+func NewSlackSink(config SlackConfig) Sink {
+	if config.DedupWindow == 0 {
+		config.DedupWindow = 5 * time.Minute
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 100
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	s := &slackSink{
+		config: config,
+		dedup:  newDeduplicator(config.DedupWindow),
+	}
+	// Slack allows about one message per second per incoming webhook.
+	s.worker = newSinkWorker(config.BufferSize, time.Second, s.send)
+	return s
+}
+
+// Name returns the sink name.
+func (s *slackSink) Name() string {
+	return "slack"
+}
+
+// Write enqueues the log if a route matches it and it is not a duplicate.
+func (s *slackSink) Write(log LogRecord) error {
+	if s.route(log) == nil {
+		return nil
+	}
+
+	if !s.dedup.allow(alertKey(log)) {
+		return nil
+	}
+
+	return s.worker.enqueue(log)
+}
+
+// Close sends the buffered messages and stops the sink.
+func (s *slackSink) Close() error {
+	s.worker.close()
+	return nil
+}
+
+// route returns the first route matching the log, or nil.
+func (s *slackSink) route(log LogRecord) *SlackRoute {
+	level, err := ParseLevel(log.ErrorLevel)
+	if err != nil {
+		return nil
+	}
+
+	category := Errorcode(log.Errorcode).Category()
+	for i, route := range s.config.Routes {
+		if level < route.MinLevel {
+			continue
+		}
+		if len(route.Categories) > 0 && !slices.Contains(route.Categories, category) {
+			continue
+		}
+		return &s.config.Routes[i]
+	}
+	return nil
+}
+
+// send delivers a single message. Delivery errors are dropped since there is nowhere to report them.
+func (s *slackSink) send(log LogRecord) {
+	route := s.route(log)
+	if route == nil {
+		return
+	}
+
+	body, err := json.Marshal(slackMessage(log))
+	if err != nil {
+		return
+	}
+
+	resp, err := s.config.HTTPClient.Post(route.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// slackMessage renders the log as a block-kit message.
+func slackMessage(log LogRecord) map[string]any {
+	title := fmt.Sprintf("%s %s · %d", slackLevelEmoji(log.ErrorLevel), strings.ToUpper(log.ErrorLevel), log.Errorcode)
+
+	fields := []map[string]any{}
+	addField := func(label, value string) {
+		if value != "" {
+			fields = append(fields, map[string]any{"type": "mrkdwn", "text": fmt.Sprintf("*%s*\n%s", label, slackEscape(value))})
+		}
+	}
+	addField("Endpoint", strings.TrimSpace(log.Method+" "+log.ApiEndpoint))
+	addField("Function", log.FunctionName)
+	addField("Event", log.EventType)
+	addField("Status", fmt.Sprint(log.StatusCode))
+	addField("Category", string(Errorcode(log.Errorcode).Category()))
+
+	blocks := []map[string]any{
+		{"type": "header", "text": map[string]any{"type": "plain_text", "text": title}},
+		{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": slackEscape(log.ClientMessageUz + "\n" + log.ClientMessageRu)}},
+		{"type": "section", "fields": fields},
+	}
+	if log.ErrorMessage != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": "```" + slackEscape(truncate(log.ErrorMessage, 2500)) + "```"},
+		})
+	}
+	blocks = append(blocks, map[string]any{
+		"type":     "context",
+		"elements": []map[string]any{{"type": "mrkdwn", "text": log.Timestamp.Format(time.RFC3339)}},
+	})
+
+	return map[string]any{
+		"text":   title, // Fallback for notifications.
+		"blocks": blocks,
+	}
+}
+
+// slackLevelEmoji returns the emoji shown in front of the level.
+func slackLevelEmoji(level string) string {
+	switch level {
+	case "critical":
+		return "🚨"
+	case "error":
+		return "🔴"
+	case "warning":
+		return "🟡"
+	default:
+		return "🔵"
+	}
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
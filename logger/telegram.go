@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TelegramConfig holds the settings of the Telegram alert sink.
+type TelegramConfig struct {
+	BotToken    string        `yaml:"bot_token"`    // Token of the Telegram bot sending the alerts.
+	ChatID      string        `yaml:"chat_id"`      // Chat, group or channel receiving the alerts.
+	MinLevel    *Level        `yaml:"min_level"`    // Minimal level sent to Telegram. Defaults to LevelCritical when nil.
+	RateLimit   int           `yaml:"rate_limit"`   // Maximum number of alerts per minute. Defaults to 20 (the Telegram group limit).
+	DedupWindow time.Duration `yaml:"dedup_window"` // Identical alerts (same code, endpoint and message) are sent once per window. Defaults to 5 minutes.
+	BufferSize  int           `yaml:"buffer_size"`  // Number of alerts buffered while waiting for the rate limit. Defaults to 100.
+	APIURL      string        `yaml:"api_url"`      // Telegram Bot API URL. Defaults to https://api.telegram.org.
+	HTTPClient  *http.Client  `yaml:"-"`            // HTTP client used for requests. Defaults to a client with a 10s timeout.
+}
+
+// telegramSink sends formatted alerts to a Telegram chat.
+type telegramSink struct {
+	config   TelegramConfig // Sink settings with defaults applied.
+	minLevel Level          // Minimal level sent to Telegram.
+	dedup    *deduplicator  // Suppresses repeated alerts.
+	worker   *sinkWorker    // Delivers alerts in the background.
+}
+
+// NewTelegramSink initializes and returns a sink sending alerts to a Telegram chat.
+// By default only critical logs are sent.
+func NewTelegramSink(config TelegramConfig) Sink {
+	minLevel := LevelCritical
+	if config.MinLevel != nil {
+		minLevel = *config.MinLevel
+	}
+	if config.RateLimit <= 0 {
+		config.RateLimit = 20
+	}
+	if config.DedupWindow == 0 {
+		config.DedupWindow = 5 * time.Minute
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 100
+	}
+	if config.APIURL == "" {
+		config.APIURL = "https://api.telegram.org"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	s := &telegramSink{
+		config:   config,
+		minLevel: minLevel,
+		dedup:    newDeduplicator(config.DedupWindow),
+	}
+	s.worker = newSinkWorker(config.BufferSize, time.Minute/time.Duration(config.RateLimit), s.send)
+	return s
+}
+
+// Name returns the sink name.
+func (s *telegramSink) Name() string {
+	return "telegram"
+}
+
+// Write enqueues the log if its level is high enough and it is not a duplicate.
+func (s *telegramSink) Write(log LogRecord) error {
+	level, err := ParseLevel(log.ErrorLevel)
+	if err != nil || level < s.minLevel {
+		return nil
+	}
+
+	if !s.dedup.allow(alertKey(log)) {
+		return nil
+	}
+
+	return s.worker.enqueue(log)
+}
+
+// Close sends the buffered alerts and stops the sink.
+func (s *telegramSink) Close() error {
+	s.worker.close()
+	return nil
+}
+
+// send delivers a single alert. Delivery errors are dropped since there is nowhere to report them.
+func (s *telegramSink) send(log LogRecord) {
+	body, err := json.Marshal(map[string]any{
+		"chat_id":                  s.config.ChatID,
+		"text":                     formatTelegramAlert(log),
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimRight(s.config.APIURL, "/"), s.config.BotToken)
+	resp, err := s.config.HTTPClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// formatTelegramAlert renders the log as an HTML formatted Telegram message.
+func formatTelegramAlert(log LogRecord) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "🚨 <b>%s</b> · <code>%d</code>\n", html.EscapeString(strings.ToUpper(log.ErrorLevel)), log.Errorcode)
+	writeTelegramField(&b, "Endpoint", strings.TrimSpace(log.Method+" "+log.ApiEndpoint))
+	writeTelegramField(&b, "Function", log.FunctionName)
+	writeTelegramField(&b, "Event", log.EventType)
+	writeTelegramField(&b, "Status", fmt.Sprint(log.StatusCode))
+	writeTelegramField(&b, "Message", log.ClientMessageUz)
+	writeTelegramField(&b, "Error", truncate(log.ErrorMessage, 1000))
+	writeTelegramField(&b, "Time", log.Timestamp.Format(time.RFC3339))
+
+	return b.String()
+}
+
+// writeTelegramField appends a bold label and escaped value, skipping empty values.
+func writeTelegramField(b *strings.Builder, label, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "<b>%s:</b> %s\n", label, html.EscapeString(value))
+}
+
+// alertKey identifies repeated alerts for deduplication.
+func alertKey(log LogRecord) string {
+	return fmt.Sprintf("%d|%s|%s|%s", log.Errorcode, log.ApiEndpoint, log.ErrorLevel, log.ErrorMessage)
+}
+
+// truncate shortens the string to at most limit runes, marking the cut with an ellipsis.
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + "…"
+}
@@ -0,0 +1,125 @@
+package logger
+
+import "sync"
+
+// Translator provides the client messages of error codes, e.g. from a database or a
+// translation-management service instead of the built-in catalog.
+type Translator interface {
+	// Translate returns the client message of the code in the language ("uz", "ru", ...),
+	// or an empty string to fall back to the built-in catalog.
+	Translate(code Errorcode, lang string) string
+}
+
+// TranslatorFunc adapts a function to the Translator interface.
+type TranslatorFunc func(code Errorcode, lang string) string
+
+// Translate calls the function.
+func (f TranslatorFunc) Translate(code Errorcode, lang string) string {
+	return f(code, lang)
+}
+
+// translators holds the translator set with SetTranslator.
+var translators = struct {
+	sync.RWMutex
+	current Translator
+}{}
+
+// SetTranslator sets the translator used by ErrorResponse, RespondError and CodedError.Response.
+// nil restores the built-in catalog.
+//
+// Usage:
+//
+//	logger.SetTranslator(logger.TranslatorFunc(func(code logger.Errorcode, lang string) string {
+//		return messages.Get(int(code), lang) // "" falls back to the catalog
+//	}))
+func SetTranslator(t Translator) {
+	translators.Lock()
+	translators.current = t
+	translators.Unlock()
+}
+
+// overrides holds the messages registered with SetMessages, by code and language.
+var overrides = struct {
+	sync.RWMutex
+	messages map[Errorcode]map[string]string
+}{messages: make(map[Errorcode]map[string]string)}
+
+// SetMessages registers the client messages of the code per language ("uz", "ru", "en", "kaa",
+// ...), overriding the built-in catalog and adding locales it lacks, e.g. Karakalpak. The
+// messages replace those registered before for the code; a nil map removes them. They are
+// used by the logger (see WithTranslator) and by ErrorResponse, RespondError and
+// CodedError.Response. A translator set with SetTranslator takes precedence.
+//
+// Usage:
+//
+//	logger.SetMessages(logger.ErrResourceNotFound, map[string]string{
+//		logger.LangKaa: "Mag'lıwmat tabılmadı",
+//		logger.LangEn:  "Product not found",
+//	})
+func SetMessages(code Errorcode, messages map[string]string) {
+	normalized := make(map[string]string, len(messages))
+	for lang, message := range messages {
+		if message != "" {
+			normalized[language(lang)] = message
+		}
+	}
+
+	overrides.Lock()
+	defer overrides.Unlock()
+	if len(normalized) == 0 {
+		delete(overrides.messages, code)
+		return
+	}
+	overrides.messages[code] = normalized
+}
+
+// override returns the message of the code in the language registered with SetMessages.
+func override(code Errorcode, lang string) string {
+	overrides.RLock()
+	defer overrides.RUnlock()
+	return overrides.messages[code][lang]
+}
+
+// translate returns the client message of the code in the most preferred language of lang
+// (a language tag or Accept-Language value) that has one, looked up in the translator, the
+// overrides of SetMessages and the catalog in that order. Without any, the catalog message of
+// the first language applies, with its fallbacks. A nil translator uses the one set with
+// SetTranslator.
+func translate(t Translator, code Errorcode, lang string) string {
+	if t == nil {
+		translators.RLock()
+		t = translators.current
+		translators.RUnlock()
+	}
+
+	preferred := languages(lang)
+	for _, tag := range preferred {
+		if t != nil {
+			if message := t.Translate(code, tag); message != "" {
+				return message
+			}
+		}
+		if message := override(code, tag); message != "" {
+			return message
+		}
+		if message := code.ClientMessage().exact(tag); message != "" {
+			return message
+		}
+	}
+	if len(preferred) == 0 {
+		return code.ClientMessage().In(LangUz)
+	}
+	return code.ClientMessage().In(preferred[0])
+}
+
+// WithTranslator fills the empty client messages of logs with the messages of their error
+// code in Uzbek and Russian. The messages come from the translator, falling back to the
+// overrides of SetMessages and the built-in catalog; nil uses the translator set with
+// SetTranslator.
+func WithTranslator(t Translator) Option {
+	return func(l *logger) {
+		l.translate = func(code Errorcode, lang string) string {
+			return translate(t, code, lang)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Transport is the interface used by the logger to deliver messages to a message broker.
+// RabbitMQ is the default implementation; other brokers (e.g. Kafka) can be plugged in
+// through `NewLoggerWithTransport`.
+type Transport interface {
+	// Declare prepares a destination (queue or topic) before messages are published to it.
+	Declare(destination string) error
+
+	// Publish delivers a single message to its destination.
+	// The message body is reused by the logger once Publish returns, so implementations
+	// that deliver asynchronously must copy it.
+	Publish(msg Message) error
+
+	// Close releases the resources held by the transport.
+	Close() error
+}
+
+// BatchTransport is implemented by transports able to deliver several messages in one round trip.
+// The async mode publishes its batches through it when available.
+type BatchTransport interface {
+	// PublishBatch delivers the messages. Like with Publish, the bodies are reused once it returns.
+	PublishBatch(msgs []Message) error
+}
+
+// Message is a single encoded message handed to a Transport.
+type Message struct {
+	Destination string            // Name of the queue or topic the message is sent to.
+	Level       string            // Log level of the message, empty for order messages.
+	Headers     map[string]string // Transport headers, e.g. the capability headers; shared between messages, must not be modified.
+	ContentType string            // MIME type of the body, see Encoding.
+	Body        []byte            // Encoded message body.
+	ExpiresAt   time.Time         // Optional time after which the broker may discard the message, see LogRequest.TTL.
+	ID          string            // Optional unique ID of the message, see Logger.LogWithID.
+}
+
+// rabbitMQTransport is the Transport implementation backed by the rabbitmq client.
+type rabbitMQTransport struct {
+	rabbitmq rabbitmq.RabbitMQ // RabbitMQ client for managing messages.
+}
+
+// NewRabbitMQTransport wraps a RabbitMQ client into a Transport.
+// Queues are declared as durable and auto-deleted, matching the logger defaults.
+// The client of rabbitmq-go reports failed publishes and reconnections through the standard
+// log package, without the message bodies; set its output with log.SetOutput to route or
+// silence them.
+func NewRabbitMQTransport(rabbitMQ rabbitmq.RabbitMQ) Transport {
+	return &rabbitMQTransport{rabbitmq: rabbitMQ}
+}
+
+// Declare declares the queue on the RabbitMQ server.
+func (t *rabbitMQTransport) Declare(destination string) error {
+	return t.rabbitmq.DeclareQueue(destination, true, true, false, false, amqp.Table{})
+}
+
+// Publish publishes the message body to the destination queue.
+// The rabbitmq client publishes every message as application/json and supports neither headers,
+// the expiration nor the message ID, which are ignored: consumers discard expired logs by their
+// `expires_at` field, see consumers.DropExpired. Loggers with a binary encoding are rejected
+// with ErrUnsupportedEncoding, see NewAMQPTransport.
+func (t *rabbitMQTransport) Publish(msg Message) error {
+	return t.rabbitmq.PublishMessage(msg.Destination, "", msg.Body)
+}
+
+// Ping publishes an empty message to the default exchange without a routing key, which the
+// broker accepts and discards, so it fails when the channel is closed. The rabbitmq client
+// supports neither publisher confirms nor contexts: the broker does not acknowledge the message,
+// and a ping abandoned at the deadline keeps retrying in the background.
+func (t *rabbitMQTransport) Ping(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- t.rabbitmq.PublishMessage("", "", []byte{})
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the RabbitMQ connection and channel.
+func (t *rabbitMQTransport) Close() error {
+	return t.rabbitmq.Close()
+}
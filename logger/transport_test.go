@@ -0,0 +1,36 @@
+package logger_test
+
+import (
+	"sync"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+// captureTransport records the published messages, copying their bodies.
+type captureTransport struct {
+	mu       sync.Mutex
+	messages []logger.Message
+}
+
+func (t *captureTransport) Declare(string) error { return nil }
+
+func (t *captureTransport) Publish(msg logger.Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	msg.Body = append([]byte(nil), msg.Body...)
+	t.messages = append(t.messages, msg)
+	return nil
+}
+
+func (t *captureTransport) Close() error { return nil }
+
+// bodies returns the bodies of the published messages, oldest first.
+func (t *captureTransport) bodies() [][]byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	bodies := make([][]byte, len(t.messages))
+	for i, msg := range t.messages {
+		bodies[i] = msg.Body
+	}
+	return bodies
+}
@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedSchemaVersion is returned for logs of a schema newer than SchemaVersion.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported log schema version")
+
+// Validation errors of published logs, both wrapping ErrInvalidLog.
+var (
+	ErrMissingTimestamp = fmt.Errorf("%w: timestamp is required", ErrInvalidLog)
+	ErrInvalidLevel     = fmt.Errorf("%w: unknown error level", ErrInvalidLog)
+)
+
+// UnmarshalLogRecord decodes a JSON log as published to the log queue, for consumers
+// reading the queue without the consumers package. It:
+//   - rejects logs of a newer schema with ErrUnsupportedSchemaVersion, and sets the version
+//     of logs predating schema_version to 1;
+//   - accepts request payloads embedded as nested JSON (see WithRawPayload);
+//   - decompresses compressed payloads (see WithPayloadCompression);
+//   - normalizes the level and method ("warn" becomes "warning", "get" becomes "GET");
+//   - validates the result, see LogRecord.Validate.
+//
+// Usage:
+//
+//	record, err := logger.UnmarshalLogRecord(msg.Body)
+//	if errors.Is(err, logger.ErrInvalidLog) {
+//		_ = msg.Reject(false)
+//	}
+func UnmarshalLogRecord(b []byte) (LogRecord, error) {
+	var record LogRecord
+	wire := struct {
+		*LogRecord
+		RequestPayload json.RawMessage `json:"request_payload"`
+	}{LogRecord: &record}
+	if err := json.Unmarshal(b, &wire); err != nil {
+		return LogRecord{}, fmt.Errorf("failed to decode log: %w", err)
+	}
+
+	if err := record.setRequestPayload(wire.RequestPayload); err != nil {
+		return LogRecord{}, err
+	}
+	if record.SchemaVersion > SchemaVersion {
+		return LogRecord{}, fmt.Errorf("%w: %d (supported up to %d)", ErrUnsupportedSchemaVersion, record.SchemaVersion, SchemaVersion)
+	}
+	if record.SchemaVersion == 0 {
+		record.SchemaVersion = 1
+	}
+	if err := record.Decompress(); err != nil {
+		return LogRecord{}, err
+	}
+
+	record.ErrorLevel = strings.ToLower(strings.TrimSpace(record.ErrorLevel))
+	if level, err := ParseLevel(record.ErrorLevel); err == nil {
+		record.ErrorLevel = level.String()
+	}
+	record.Method = normalizeMethod(record.Method)
+
+	if err := record.Validate(); err != nil {
+		return LogRecord{}, err
+	}
+	return record, nil
+}
+
+// setRequestPayload sets the request payload from its JSON value: a string, or a nested JSON value.
+func (r *LogRecord) setRequestPayload(raw json.RawMessage) error {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	if raw[0] != '"' {
+		r.RequestPayload = string(raw)
+		return nil
+	}
+	if err := json.Unmarshal(raw, &r.RequestPayload); err != nil {
+		return fmt.Errorf("failed to decode request payload: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the fields every published log has: a timestamp, a known level and an
+// error code. It returns a *ValidationError listing all problems. Producer-side rules
+// (see ValidationPolicy) are not checked, since logs may be published despite failing them.
+func (r *LogRecord) Validate() error {
+	var errs []error
+	if r.Timestamp.IsZero() {
+		errs = append(errs, ErrMissingTimestamp)
+	}
+
+	if r.ErrorLevel == "" {
+		errs = append(errs, ErrMissingLevel)
+	} else if _, err := ParseLevel(r.ErrorLevel); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %q", ErrInvalidLevel, r.ErrorLevel))
+	}
+
+	if r.Errorcode == 0 && !r.ValidationFailed {
+		errs = append(errs, ErrMissingErrorCode)
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// Decompress restores the payload fields compressed by the producer (see WithPayloadCompression)
+// and clears their encodings. Binary fields stay base64 encoded, flagged with PayloadEncodingBase64.
+// Empty and offloaded fields are left untouched, their encoding describing the stored payload.
+func (r *LogRecord) Decompress() error {
+	if r.RequestPayloadEncoding != "" && r.RequestPayloadEncoding != PayloadEncodingBase64 && r.RequestPayload != "" && r.RequestPayloadRef == nil {
+		value, err := decompressPayload(r.RequestPayload, r.RequestPayloadEncoding)
+		if err != nil {
+			return fmt.Errorf("failed to decompress request payload: %w", err)
+		}
+		r.RequestPayload, r.RequestPayloadEncoding = value, ""
+	}
+	if r.ResponseDataEncoding != "" && r.ResponseDataEncoding != PayloadEncodingBase64 && r.ResponseData != "" && r.ResponseDataRef == nil {
+		value, err := decompressPayload(r.ResponseData, r.ResponseDataEncoding)
+		if err != nil {
+			return fmt.Errorf("failed to decompress response data: %w", err)
+		}
+		r.ResponseData, r.ResponseDataEncoding = value, ""
+	}
+	return nil
+}
+
+// Expired reports whether the log is past its `expires_at` time, see LogRequest.TTL.
+func (r *LogRecord) Expired(now time.Time) bool {
+	return r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
+
+// decompressPayload decodes a single field value.
+func decompressPayload(value, encoding string) (string, error) {
+	if encoding != PayloadEncodingGzip {
+		return "", fmt.Errorf("unknown payload encoding %q", encoding)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,167 @@
+package logger
+
+import "net/http"
+
+// Validator is a custom validation rule. It receives the log as passed to the level method
+// and returns an error describing the problem, or nil for valid logs.
+type Validator func(level Level, log LogRequest) error
+
+// ValidationPolicy configures the rules logs are validated with.
+type ValidationPolicy struct {
+	// PayloadLevels are the levels requiring a request payload. Nil keeps the default
+	// (error and critical); an empty non-nil slice requires it for no level.
+	// Ignored when RequiredFields is set.
+	PayloadLevels []Level
+
+	// RequiredFields lists the fields each level requires, replacing the payload rule of
+	// PayloadLevels when not nil. Levels missing from the map require no field.
+	//
+	//	RequiredFields: map[logger.Level][]logger.Field{
+	//		logger.LevelError:    {logger.FieldRequestPayload},
+	//		logger.LevelCritical: {logger.FieldRequestPayload, logger.FieldErrorMessage},
+	//	}
+	RequiredFields map[Level][]Field
+
+	// AllowEmptyClientMessage accepts logs without any client message, e.g. for
+	// internal services whose errors never reach end users.
+	AllowEmptyClientMessage bool
+
+	// Methods are the accepted values of Method, compared after uppercasing. Nil accepts the
+	// standard HTTP methods; an empty non-nil slice accepts any method. Logs without a method
+	// are always accepted.
+	Methods []string
+
+	// RegisteredEventTypes rejects logs whose event type was not registered with
+	// RegisterEventType. Logs without an event type are always accepted.
+	RegisteredEventTypes bool
+
+	// Validators are additional rules checked after the built-in ones.
+	Validators []Validator
+
+	// PublishInvalid publishes logs failing validation instead of rejecting them, with
+	// validation_failed set and the problems in validation_error, so the evidence of an
+	// error is not lost because its metadata was incomplete. The level methods then
+	// return nil unless publishing fails.
+	PublishInvalid bool
+}
+
+// Field names a field of a log that a level may require, see ValidationPolicy.RequiredFields.
+type Field string
+
+// Fields that can be required.
+const (
+	FieldRequestPayload Field = "request_payload"
+	FieldErrorMessage   Field = "error_message"
+	FieldDetails        Field = "details" // Details in Uzbek or Russian.
+	FieldApiEndpoint    Field = "api_endpoint"
+	FieldMethod         Field = "method"
+	FieldEventType      Field = "event_type"
+	FieldResponseData   Field = "response_data"
+	FieldMerchantApiKey Field = "merchant_api_key"
+	FieldDurationMs     Field = "duration_ms"
+	FieldUserID         Field = "user_id"
+	FieldRequestID      Field = "request_id"
+)
+
+// present reports whether the field is set in the log.
+func (f Field) present(log *LogRecord) bool {
+	switch f {
+	case FieldRequestPayload:
+		return log.RequestPayload != "" || log.payload != nil
+	case FieldErrorMessage:
+		return log.ErrorMessage != ""
+	case FieldDetails:
+		return log.DetailsUz != "" || log.DetailsRu != ""
+	case FieldApiEndpoint:
+		return log.ApiEndpoint != ""
+	case FieldMethod:
+		return log.Method != ""
+	case FieldEventType:
+		return log.EventType != ""
+	case FieldResponseData:
+		return log.ResponseData != ""
+	case FieldMerchantApiKey:
+		return log.MerchantApiKey != ""
+	case FieldDurationMs:
+		return log.DurationMs != 0
+	case FieldUserID:
+		return log.UserID != ""
+	case FieldRequestID:
+		return log.RequestID != ""
+	default:
+		return true
+	}
+}
+
+// validation is a ValidationPolicy prepared for checks on every log.
+type validation struct {
+	requiredFields          map[string][]Field // Fields required per level name.
+	allowEmptyClientMessage bool               // Whether logs may have no client message.
+	validators              []Validator        // Custom rules.
+	publishInvalid          bool               // Whether invalid logs are published marked as such.
+	methods                 map[string]bool    // Accepted methods, nil accepts any.
+	registeredEventTypes    bool               // Whether event types must be registered.
+}
+
+// httpMethods are the methods accepted by default.
+var httpMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// defaultValidation holds the rules used without WithValidation.
+var defaultValidation = newValidation(ValidationPolicy{})
+
+// newValidation prepares the policy.
+func newValidation(policy ValidationPolicy) *validation {
+	required := policy.RequiredFields
+	if required == nil {
+		levels := policy.PayloadLevels
+		if levels == nil {
+			levels = []Level{LevelError, LevelCritical}
+		}
+		required = make(map[Level][]Field, len(levels))
+		for _, level := range levels {
+			required[level] = []Field{FieldRequestPayload}
+		}
+	}
+
+	v := &validation{
+		requiredFields:          make(map[string][]Field, len(required)),
+		allowEmptyClientMessage: policy.AllowEmptyClientMessage,
+		validators:              policy.Validators,
+		publishInvalid:          policy.PublishInvalid,
+		registeredEventTypes:    policy.RegisteredEventTypes,
+	}
+	for level, fields := range required {
+		v.requiredFields[level.String()] = fields
+	}
+
+	methods := policy.Methods
+	if methods == nil {
+		methods = httpMethods
+	}
+	if len(methods) > 0 {
+		v.methods = make(map[string]bool, len(methods))
+		for _, method := range methods {
+			v.methods[normalizeMethod(method)] = true
+		}
+	}
+	return v
+}
+
+// WithValidation replaces the default validation rules. The error code stays required.
+//
+// Usage:
+//
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", nil, nil,
+//		logger.WithValidation(logger.ValidationPolicy{
+//			PayloadLevels:           []logger.Level{logger.LevelCritical},
+//			AllowEmptyClientMessage: true,
+//		}),
+//	)
+func WithValidation(policy ValidationPolicy) Option {
+	return func(l *logger) {
+		l.validation = newValidation(policy)
+	}
+}
@@ -0,0 +1,215 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// WebhookFilter selects the logs sent to a webhook destination. Empty fields match everything.
+type WebhookFilter struct {
+	MinLevel   Level       // Minimal level sent to the destination.
+	Codes      []Errorcode // Error codes sent to the destination.
+	Categories []Category  // Error code categories sent to the destination.
+}
+
+// WebhookDestination describes one HTTP endpoint receiving logs.
+type WebhookDestination struct {
+	Name            string            // Name of the destination, used in errors.
+	URL             string            // Endpoint the logs are POSTed to.
+	Template        string            // Go template rendering the body from the log; empty sends the log as JSON.
+	ContentType     string            // Content type of the body. Defaults to application/json.
+	Headers         map[string]string // Additional request headers.
+	Secret          string            // HMAC-SHA256 key signing the body; empty disables signing.
+	SignatureHeader string            // Header carrying the "sha256=<hex>" signature. Defaults to X-Signature.
+	Filter          WebhookFilter     // Selects the logs sent to the destination.
+	MaxRetries      int               // Retries after a failed delivery (network error, 429 or 5xx). Defaults to 3; negative disables retries.
+	Concurrency     int               // Maximum number of parallel requests to the destination. Defaults to 4.
+	BufferSize      int               // Number of logs buffered while waiting for delivery. Defaults to 1000.
+}
+
+// WebhookConfig holds the settings of the HTTP webhook sink.
+type WebhookConfig struct {
+	Destinations []WebhookDestination // Endpoints receiving logs; a log is sent to every matching destination.
+	HTTPClient   *http.Client         // HTTP client used for requests. Defaults to a client with a 10s timeout.
+}
+
+// webhookSink POSTs logs to arbitrary HTTP endpoints.
+type webhookSink struct {
+	destinations []*webhookDestination // Destinations with their templates and workers.
+}
+
+// webhookDestination is a destination prepared for delivery.
+type webhookDestination struct {
+	WebhookDestination
+	template *template.Template // Parsed body template, nil for JSON bodies.
+	client   *http.Client       // HTTP client used for requests.
+	worker   *sinkWorker        // Delivers logs with the destination concurrency.
+}
+
+// webhookTemplateFuncs are the functions available in webhook body templates.
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"category": func(code int) string {
+		return string(Errorcode(code).Category())
+	},
+}
+
+// NewWebhookSink initializes and returns a sink POSTing logs to HTTP endpoints.
+// Templates are executed with the published log as data, e.g.
+// `{"text": {{printf "%d %s" .Errorcode .ErrorMessage | json}}}`.
+// It returns an error if a destination template cannot be parsed.
+func NewWebhookSink(config WebhookConfig) (Sink, error) {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	s := &webhookSink{}
+	for _, dest := range config.Destinations {
+		if dest.ContentType == "" {
+			dest.ContentType = "application/json"
+		}
+		if dest.SignatureHeader == "" {
+			dest.SignatureHeader = "X-Signature"
+		}
+		if dest.MaxRetries == 0 {
+			dest.MaxRetries = 3
+		}
+		if dest.MaxRetries < 0 {
+			dest.MaxRetries = 0 // One attempt, without retries.
+		}
+		if dest.Concurrency <= 0 {
+			dest.Concurrency = 4
+		}
+		if dest.BufferSize <= 0 {
+			dest.BufferSize = 1000
+		}
+
+		d := &webhookDestination{WebhookDestination: dest, client: config.HTTPClient}
+		if dest.Template != "" {
+			tmpl, err := template.New(dest.Name).Funcs(webhookTemplateFuncs).Parse(dest.Template)
+			if err != nil {
+				s.Close()
+				return nil, fmt.Errorf("failed to parse template of webhook %q: %w", dest.Name, err)
+			}
+			d.template = tmpl
+		}
+		d.worker = newSinkWorkerPool(dest.BufferSize, dest.Concurrency, d.send)
+		s.destinations = append(s.destinations, d)
+	}
+
+	return s, nil
+}
+
+// Name returns the sink name.
+func (s *webhookSink) Name() string {
+	return "webhook"
+}
+
+// Write enqueues the log for every destination whose filter matches it.
+func (s *webhookSink) Write(log LogRecord) error {
+	var errs []error
+	for _, d := range s.destinations {
+		if !d.Filter.match(log) {
+			continue
+		}
+		if err := d.worker.enqueue(log); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %q: %w", d.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close delivers the buffered logs and stops the sink.
+func (s *webhookSink) Close() error {
+	for _, d := range s.destinations {
+		d.worker.close()
+	}
+	return nil
+}
+
+// match reports whether the log passes the filter.
+func (f WebhookFilter) match(log LogRecord) bool {
+	level, err := ParseLevel(log.ErrorLevel)
+	if err != nil || level < f.MinLevel {
+		return false
+	}
+	if len(f.Codes) > 0 && !slices.Contains(f.Codes, Errorcode(log.Errorcode)) {
+		return false
+	}
+	if len(f.Categories) > 0 && !slices.Contains(f.Categories, Errorcode(log.Errorcode).Category()) {
+		return false
+	}
+	return true
+}
+
+// send renders and delivers a single log, retrying with exponential backoff.
+// Delivery errors are dropped after the last retry since there is nowhere to report them.
+func (d *webhookDestination) send(log LogRecord) {
+	body, err := d.render(log)
+	if err != nil {
+		return
+	}
+
+	for attempt := 0; attempt <= d.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(1<<(attempt-1)) * time.Second)
+		}
+		if !d.post(body) {
+			return
+		}
+	}
+}
+
+// render produces the request body from the template, or JSON without a template.
+func (d *webhookDestination) render(log LogRecord) ([]byte, error) {
+	if d.template == nil {
+		return json.Marshal(log)
+	}
+
+	var buf bytes.Buffer
+	if err := d.template.Execute(&buf, log); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends the body once and reports whether the delivery should be retried.
+func (d *webhookDestination) post(body []byte) (retry bool) {
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	req.Header.Set("Content-Type", d.ContentType)
+	for key, value := range d.Headers {
+		req.Header.Set(key, value)
+	}
+	if d.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(d.Secret))
+		mac.Write(body)
+		req.Header.Set(d.SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
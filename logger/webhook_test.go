@@ -0,0 +1,42 @@
+package logger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+)
+
+func TestWebhookRetries(t *testing.T) {
+	for _, tt := range []struct {
+		maxRetries int
+		want       int64
+	}{
+		{maxRetries: -1, want: 1},
+		{maxRetries: 1, want: 2},
+	} {
+		var requests atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		sink, err := logger.NewWebhookSink(logger.WebhookConfig{
+			Destinations: []logger.WebhookDestination{{Name: "alerts", URL: server.URL, MaxRetries: tt.maxRetries}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Write(logger.LogRecord{ErrorLevel: "error", Errorcode: int(logger.ErrInternalServer)}); err != nil {
+			t.Fatal(err)
+		}
+		sink.Close()
+		server.Close()
+
+		if n := requests.Load(); n != tt.want {
+			t.Errorf("MaxRetries %d: %d requests, want %d", tt.maxRetries, n, tt.want)
+		}
+	}
+}
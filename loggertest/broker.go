@@ -0,0 +1,196 @@
+package loggertest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/testcontainers/testcontainers-go"
+	tcrabbitmq "github.com/testcontainers/testcontainers-go/modules/rabbitmq"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Image is the RabbitMQ image started by StartBroker.
+var Image = "rabbitmq:3.13-alpine"
+
+// Timeout bounds the broker startup and the waits of the assertions.
+var Timeout = 60 * time.Second
+
+// RequireDockerEnv names the environment variable making StartBroker fail instead of skipping
+// the test when docker is not available, so CI cannot silently skip the end-to-end tests.
+const RequireDockerEnv = "LOGGERTEST_REQUIRE_DOCKER"
+
+// Broker is a RabbitMQ broker running in a container for the duration of a test.
+type Broker struct {
+	URL string // AMQP URL of the broker.
+}
+
+// StartBroker starts a RabbitMQ container with testcontainers and waits until the broker has
+// started and its AMQP port accepts connections. The container is removed when the test ends.
+// The test is skipped when docker is not available, unless RequireDockerEnv is set, so suites
+// using it still pass on machines without docker.
+//
+// Usage:
+//
+//	func TestCreateOrderLogs(t *testing.T) {
+//		broker := loggertest.StartBroker(t)
+//		log := broker.NewLogger(t, "logs")
+//		NewService(log).CreateOrder(ctx, order)
+//
+//		record := broker.ExpectLog(t, "logs", func(r consumers.Record) bool {
+//			return r.Errorcode == int(logger.ErrInvalidData)
+//		})
+//		if record.ApiEndpoint != "/api/v1/orders" {
+//			t.Errorf("unexpected endpoint %q", record.ApiEndpoint)
+//		}
+//	}
+func StartBroker(t testing.TB) *Broker {
+	t.Helper()
+	if err := dockerHealth(); err != nil {
+		if os.Getenv(RequireDockerEnv) != "" {
+			t.Fatalf("loggertest: docker is not available: %v", err)
+		}
+		t.Skipf("loggertest: docker is not available: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	container, err := tcrabbitmq.Run(ctx, Image,
+		testcontainers.WithWaitStrategyAndDeadline(Timeout,
+			wait.ForLog("Server startup complete"),
+			wait.ForListeningPort("5672/tcp"),
+		),
+	)
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("loggertest: failed to start broker: %v", err)
+	}
+
+	url, err := container.AmqpURL(ctx)
+	if err != nil {
+		t.Fatalf("loggertest: failed to get broker URL: %v", err)
+	}
+	return &Broker{URL: url}
+}
+
+// dockerHealth returns an error when the docker daemon cannot be reached.
+func dockerHealth() (err error) {
+	// The provider panics when docker is not configured at all.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err != nil {
+		return err
+	}
+	defer provider.Close()
+	return provider.Health(context.Background())
+}
+
+// NewLogger returns a logger publishing to the queue of the broker, closed when the test ends.
+func (b *Broker) NewLogger(t testing.TB, queue string, opts ...logger.Option) logger.Logger {
+	t.Helper()
+
+	rabbitMQ, err := rabbitmq.NewRabbitMQ(b.URL, nil)
+	if err != nil {
+		t.Fatalf("loggertest: failed to connect to broker: %v", err)
+	}
+	log, err := logger.NewLogger(rabbitMQ, queue, t.Name(), "/loggertest", nil, nil, opts...)
+	if err != nil {
+		_ = rabbitMQ.Close()
+		t.Fatalf("loggertest: failed to create logger: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+		defer cancel()
+		_ = log.Close(ctx)
+		_ = rabbitMQ.Close()
+	})
+	return log
+}
+
+// Records takes n logs from the queue, waiting for them until Timeout, and fails the test if
+// fewer arrive. The logs are removed from the queue.
+func (b *Broker) Records(t testing.TB, queue string, n int) []consumers.Record {
+	t.Helper()
+
+	var records []consumers.Record
+	err := b.take(queue, func(d consumers.Delivery) bool {
+		records = append(records, d.Record)
+		return len(records) == n
+	})
+	if err != nil {
+		t.Fatalf("loggertest: got %d of %d logs from %q: %v", len(records), n, queue, err)
+	}
+	return records
+}
+
+// ExpectLog takes logs from the queue until one matches, waiting for it until Timeout, and
+// fails the test if none does. The logs taken before the match are removed from the queue.
+func (b *Broker) ExpectLog(t testing.TB, queue string, match func(r consumers.Record) bool) consumers.Record {
+	t.Helper()
+
+	var found consumers.Record
+	seen := 0
+	err := b.take(queue, func(d consumers.Delivery) bool {
+		seen++
+		if match(d.Record) {
+			found = d.Record
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		t.Fatalf("loggertest: no matching log among %d logs from %q: %v", seen, queue, err)
+	}
+	return found
+}
+
+// take gets messages from the queue and hands them to fn until it returns true. Messages are
+// fetched one by one with basic.get, so the auto-deleted log queue outlives the assertion.
+func (b *Broker) take(queue string, fn func(d consumers.Delivery) bool) error {
+	conn, err := amqp.Dial(b.URL)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+
+	deadline := time.Now().Add(Timeout)
+	for {
+		msg, ok, err := ch.Get(queue, true)
+		if err != nil {
+			return fmt.Errorf("failed to get message: %w", err)
+		}
+		if !ok {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out after %s", Timeout)
+			}
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		d, err := consumers.Decode(msg.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode log: %w", err)
+		}
+		if fn(d) {
+			return nil
+		}
+	}
+}
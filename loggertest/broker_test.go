@@ -0,0 +1,42 @@
+package loggertest_test
+
+import (
+	"testing"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/kupalovmuhammadjon/mybazar-logger/loggertest"
+)
+
+func TestBroker(t *testing.T) {
+	broker := loggertest.StartBroker(t)
+	log := broker.NewLogger(t, "logs")
+
+	if err := log.Info(logger.LogRequest{Errorcode: logger.InfoRequestProcessed, ClientMessageUz: "Tayyor"}); err != nil {
+		t.Fatal(err)
+	}
+	err := log.Error(logger.LogRequest{
+		Errorcode:       logger.ErrInvalidData,
+		ClientMessageUz: "Xato",
+		ErrorMessage:    "invalid quantity",
+		RequestPayload:  `{"qty":-1}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := broker.ExpectLog(t, "logs", func(r consumers.Record) bool {
+		return r.Errorcode == int(logger.ErrInvalidData)
+	})
+	if record.ApiEndpoint != "/loggertest" || record.FunctionName != t.Name() {
+		t.Errorf("unexpected origin %q %q", record.FunctionName, record.ApiEndpoint)
+	}
+
+	if err := log.Warn(logger.LogRequest{Errorcode: logger.WarnHighResponseTime, ClientMessageUz: "Sekin"}); err != nil {
+		t.Fatal(err)
+	}
+	records := broker.Records(t, "logs", 1)
+	if records[0].Errorcode != int(logger.WarnHighResponseTime) {
+		t.Errorf("Records returned code %d, want %d", records[0].Errorcode, logger.WarnHighResponseTime)
+	}
+}
@@ -0,0 +1,108 @@
+package logmetrics
+
+import (
+	"net/http"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// collector reads the metrics from the logger diagnostics on every scrape.
+type collector struct {
+	logger    logger.Logger     // Logger reported on.
+	published *prometheus.Desc  // Messages accepted by the transport.
+	failed    *prometheus.Desc  // Messages the transport failed to deliver.
+	buffered  *prometheus.Desc  // Logs waiting in the async buffer.
+	dropped   *prometheus.Desc  // Logs dropped because the async buffer was full.
+	connected *prometheus.Desc  // Whether the last delivery succeeded.
+	lastPub   *prometheus.Desc  // Time of the last successful delivery.
+	sinks     *prometheus.Desc  // Whether each attached sink is enabled.
+	labels    prometheus.Labels // Constant labels of the logger.
+}
+
+// NewCollector returns a Prometheus collector of what the logger of a service delivered,
+// buffered and dropped (consumers/metrics derives metrics from the log stream instead), named with the namespace
+// (defaults to "mybazar") and labeled with the queue and the function name of the logger:
+//
+//   - <namespace>_logger_published_total: messages accepted by the transport.
+//   - <namespace>_logger_publish_failures_total: messages the transport failed to deliver.
+//   - <namespace>_logger_buffered_logs: logs waiting in the async buffer.
+//   - <namespace>_logger_dropped_total: logs dropped because the async buffer was full.
+//   - <namespace>_logger_connected: 1 while deliveries succeed, 0 after a failed one.
+//   - <namespace>_logger_last_publish_timestamp_seconds: time of the last successful delivery.
+//   - <namespace>_logger_sink_enabled{sink}: 1 for enabled sinks, 0 for disabled ones.
+func NewCollector(l logger.Logger, namespace string) prometheus.Collector {
+	if namespace == "" {
+		namespace = "mybazar"
+	}
+	d := l.Diagnostics()
+	labels := prometheus.Labels{"queue": d.Queue, "function": d.Config.FunctionName}
+	desc := func(name, help string, variable ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "logger", name), help, variable, labels)
+	}
+
+	return &collector{
+		logger:    l,
+		published: desc("published_total", "Messages accepted by the transport."),
+		failed:    desc("publish_failures_total", "Messages the transport failed to deliver."),
+		buffered:  desc("buffered_logs", "Logs waiting in the async buffer."),
+		dropped:   desc("dropped_total", "Logs dropped because the async buffer was full."),
+		connected: desc("connected", "1 while deliveries succeed, 0 after a failed one."),
+		lastPub:   desc("last_publish_timestamp_seconds", "Unix time of the last successful delivery."),
+		sinks:     desc("sink_enabled", "1 for enabled sinks, 0 for sinks disabled at runtime.", "sink"),
+		labels:    labels,
+	}
+}
+
+// Describe sends the descriptors of the metrics.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{c.published, c.failed, c.buffered, c.dropped, c.connected, c.lastPub, c.sinks} {
+		ch <- desc
+	}
+}
+
+// Collect sends the current values of the metrics.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	d := c.logger.Diagnostics()
+
+	ch <- prometheus.MustNewConstMetric(c.published, prometheus.CounterValue, float64(d.Published))
+	ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(d.Failed))
+	ch <- prometheus.MustNewConstMetric(c.buffered, prometheus.GaugeValue, float64(d.Buffered))
+	ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(d.Dropped))
+	ch <- prometheus.MustNewConstMetric(c.connected, prometheus.GaugeValue, boolValue(d.Connected))
+	if d.LastPublishAt != nil {
+		ch <- prometheus.MustNewConstMetric(c.lastPub, prometheus.GaugeValue, float64(d.LastPublishAt.UnixNano())/1e9)
+	}
+	for name, enabled := range d.Config.Sinks {
+		ch <- prometheus.MustNewConstMetric(c.sinks, prometheus.GaugeValue, boolValue(enabled), name)
+	}
+}
+
+// boolValue converts a boolean into a 0 or 1 metric value.
+func boolValue(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// Handler returns the HTTP handler for /metrics serving, from a registry of its own, the metrics
+// of the logger, the Go runtime and process collectors, and the given collectors, e.g. those
+// of the broker client. The rabbitmq client exposes no collectors of its own; the delivery
+// metrics of the logger cover the publishing side of the connection.
+//
+// Usage:
+//
+//	mux.Handle("/metrics", logmetrics.Handler(log))
+func Handler(l logger.Logger, extra ...prometheus.Collector) http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		NewCollector(l, ""),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	registry.MustRegister(extra...)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,120 @@
+package logquery
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers/clickhouse"
+)
+
+// clickHouseStore queries the `logs` table written by consumers/clickhouse.
+type clickHouseStore struct {
+	config clickhouse.Config // Connection settings.
+}
+
+// NewClickHouseStore returns a Store reading from the ClickHouse log table.
+func NewClickHouseStore(config clickhouse.Config) Store {
+	if config.Database == "" {
+		config.Database = "default"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &clickHouseStore{config: config}
+}
+
+// Query returns the logs matching the query, newest first.
+// Filter values are sent as query parameters, never interpolated into the SQL.
+func (s *clickHouseStore) Query(ctx context.Context, q Query) ([]consumers.Record, error) {
+	params := url.Values{}
+	params.Set("database", s.config.Database)
+	params.Set("date_time_output_format", "iso")
+
+	var conditions []string
+	add := func(condition, name, value string) {
+		conditions = append(conditions, condition)
+		params.Set("param_"+name, value)
+	}
+	if !q.From.IsZero() {
+		add("timestamp >= parseDateTime64BestEffort({from:String}, 3)", "from", q.From.UTC().Format(time.RFC3339Nano))
+	}
+	if !q.To.IsZero() {
+		add("timestamp < parseDateTime64BestEffort({to:String}, 3)", "to", q.To.UTC().Format(time.RFC3339Nano))
+	}
+	if q.Errorcode != 0 {
+		add("error_code = {code:UInt16}", "code", fmt.Sprint(q.Errorcode))
+	}
+	if q.Level != "" {
+		add("error_level = {level:String}", "level", q.Level)
+	}
+	if q.MerchantApiKey != "" {
+		add("merchant_api_key = {merchant:String}", "merchant", q.MerchantApiKey)
+	}
+	if q.ApiEndpoint != "" {
+		add("api_endpoint = {endpoint:String}", "endpoint", q.ApiEndpoint)
+	}
+	if q.GroupID != "" {
+		add("group_id = {group:String}", "group", q.GroupID)
+	}
+	if q.UserID != "" {
+		add("user_id = {user:String}", "user", q.UserID)
+	}
+	if q.SessionID != "" {
+		add("session_id = {session:String}", "session", q.SessionID)
+	}
+	if q.ClientIP != "" {
+		add("client_ip = {ip:String}", "ip", q.ClientIP)
+	}
+	if q.RequestID != "" {
+		add("request_id = {request:String}", "request", q.RequestID)
+	}
+	if q.Environment != "" {
+		add("environment = {environment:String}", "environment", q.Environment)
+	}
+
+	query := "SELECT * EXCEPT (id, received_at) FROM logs FINAL"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY timestamp DESC LIMIT %d FORMAT JSONEachRow", q.limit())
+
+	endpoint := strings.TrimRight(s.config.URL, "/") + "/?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	if s.config.Username != "" {
+		req.SetBasicAuth(s.config.Username, s.config.Password)
+	}
+
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("clickhouse returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	var records []consumers.Record
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var r consumers.Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("failed to decode row: %w", err)
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
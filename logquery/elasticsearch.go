@@ -0,0 +1,129 @@
+package logquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers/elasticsearch"
+)
+
+// elasticsearchStore queries the daily indices written by consumers/elasticsearch.
+type elasticsearchStore struct {
+	config elasticsearch.Config // Connection settings.
+}
+
+// NewElasticsearchStore returns a Store reading from the Elasticsearch/OpenSearch daily indices.
+func NewElasticsearchStore(config elasticsearch.Config) Store {
+	if config.IndexPrefix == "" {
+		config.IndexPrefix = "logs"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &elasticsearchStore{config: config}
+}
+
+// Query returns the logs matching the query, newest first.
+func (s *elasticsearchStore) Query(ctx context.Context, q Query) ([]consumers.Record, error) {
+	var filters []map[string]any
+	term := func(field string, value any) {
+		filters = append(filters, map[string]any{"term": map[string]any{field: value}})
+	}
+	if !q.From.IsZero() || !q.To.IsZero() {
+		timeRange := map[string]any{}
+		if !q.From.IsZero() {
+			timeRange["gte"] = q.From.UTC().Format(time.RFC3339Nano)
+		}
+		if !q.To.IsZero() {
+			timeRange["lt"] = q.To.UTC().Format(time.RFC3339Nano)
+		}
+		filters = append(filters, map[string]any{"range": map[string]any{"timestamp": timeRange}})
+	}
+	if q.Errorcode != 0 {
+		term("error_code", q.Errorcode)
+	}
+	if q.Level != "" {
+		term("error_level", q.Level)
+	}
+	if q.MerchantApiKey != "" {
+		term("merchant_api_key", q.MerchantApiKey)
+	}
+	if q.ApiEndpoint != "" {
+		term("api_endpoint", q.ApiEndpoint)
+	}
+	if q.GroupID != "" {
+		term("group_id", q.GroupID)
+	}
+	if q.UserID != "" {
+		term("user_id", q.UserID)
+	}
+	if q.SessionID != "" {
+		term("session_id", q.SessionID)
+	}
+	if q.ClientIP != "" {
+		term("client_ip", q.ClientIP)
+	}
+	if q.RequestID != "" {
+		term("request_id", q.RequestID)
+	}
+	if q.Environment != "" {
+		term("environment", q.Environment)
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"size":  q.limit(),
+		"sort":  []map[string]any{{"timestamp": "desc"}},
+		"query": map[string]any{"bool": map[string]any{"filter": filters}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s-*/_search?ignore_unavailable=true", strings.TrimRight(s.config.URL, "/"), s.config.IndexPrefix)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch {
+	case s.config.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+s.config.APIKey)
+	case s.config.Username != "":
+		req.SetBasicAuth(s.config.Username, s.config.Password)
+	}
+
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("cluster returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				Source consumers.Record `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	records := make([]consumers.Record, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		records = append(records, hit.Source)
+	}
+	return records, nil
+}
@@ -0,0 +1,76 @@
+package logquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+)
+
+// Query describes the logs to look up. Empty fields are not filtered on.
+type Query struct {
+	From           time.Time // Start of the time range (inclusive).
+	To             time.Time // End of the time range (exclusive).
+	Errorcode      int       // Error code of the logs.
+	Level          string    // Level of the logs, as published in `error_level`.
+	MerchantApiKey string    // Merchant API key of the logs.
+	ApiEndpoint    string    // API endpoint of the logs.
+	GroupID        string    // Group ID of the logs, see logger.Logger.Group.
+	UserID         string    // User of the logs, see logger.ContextWithUser.
+	SessionID      string    // Session of the logs, see logger.ContextWithUser.
+	ClientIP       string    // IP address of the client of the logs.
+	RequestID      string    // ID of the request of the logs.
+	Environment    string    // Environment of the producer of the logs, e.g. "production".
+	Limit          int       // Maximum number of logs returned, newest first. Defaults to 100.
+}
+
+// Store is a hot log store that can be queried.
+type Store interface {
+	// Query returns the logs matching the query, newest first.
+	Query(ctx context.Context, q Query) ([]consumers.Record, error)
+}
+
+// limit returns the query limit with the default applied.
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return 100
+	}
+	return q.Limit
+}
+
+// PrintJSON writes the logs as a JSON array.
+func PrintJSON(w io.Writer, records []consumers.Record) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// PrintTable writes the logs as an aligned text table.
+func PrintTable(w io.Writer, records []consumers.Record) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tLEVEL\tCODE\tSTATUS\tENDPOINT\tFUNCTION\tMESSAGE")
+	for _, r := range records {
+		message := r.ErrorMessage
+		if message == "" {
+			message = r.ClientMessageUz
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			r.Timestamp.Format(time.RFC3339), r.ErrorLevel, r.Errorcode, r.StatusCode,
+			strings.TrimSpace(r.Method+" "+r.ApiEndpoint), r.FunctionName, oneLine(message, 120))
+	}
+	return tw.Flush()
+}
+
+// oneLine collapses the message to a single line of at most limit runes.
+func oneLine(s string, limit int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > limit {
+		return string(runes[:limit]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,98 @@
+package logquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+)
+
+// postgresStore queries the `logs` table written by consumers/postgres.
+type postgresStore struct {
+	db *sql.DB // Database connection pool.
+}
+
+// NewPostgresStore returns a Store reading from the Postgres log table.
+func NewPostgresStore(db *sql.DB) Store {
+	return &postgresStore{db: db}
+}
+
+// Query returns the logs matching the query, newest first.
+func (s *postgresStore) Query(ctx context.Context, q Query) ([]consumers.Record, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if !q.From.IsZero() {
+		add("timestamp >= $%d", q.From)
+	}
+	if !q.To.IsZero() {
+		add("timestamp < $%d", q.To)
+	}
+	if q.Errorcode != 0 {
+		add("error_code = $%d", q.Errorcode)
+	}
+	if q.Level != "" {
+		add("error_level = $%d", q.Level)
+	}
+	if q.MerchantApiKey != "" {
+		add("merchant_api_key = $%d", q.MerchantApiKey)
+	}
+	if q.ApiEndpoint != "" {
+		add("api_endpoint = $%d", q.ApiEndpoint)
+	}
+	if q.GroupID != "" {
+		add("group_id = $%d", q.GroupID)
+	}
+	if q.UserID != "" {
+		add("user_id = $%d", q.UserID)
+	}
+	if q.SessionID != "" {
+		add("session_id = $%d", q.SessionID)
+	}
+	if q.ClientIP != "" {
+		add("client_ip = $%d", q.ClientIP)
+	}
+	if q.RequestID != "" {
+		add("request_id = $%d", q.RequestID)
+	}
+	if q.Environment != "" {
+		add("environment = $%d", q.Environment)
+	}
+
+	query := `SELECT timestamp, error_level, error_code, client_message_uz, client_message_ru, error_message,
+		details_uz, details_ru, api_endpoint, method, function_name, status_code, request_payload,
+		event_type, response_data, merchant_api_key, group_id, user_id, session_id, client_ip, user_agent, request_id,
+		environment, duration_ms FROM logs`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, q.limit())
+	query += fmt.Sprintf(" ORDER BY timestamp DESC LIMIT $%d", len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", err)
+	}
+	defer rows.Close()
+
+	var records []consumers.Record
+	for rows.Next() {
+		var r consumers.Record
+		err := rows.Scan(&r.Timestamp, &r.ErrorLevel, &r.Errorcode, &r.ClientMessageUz, &r.ClientMessageRu, &r.ErrorMessage,
+			&r.DetailsUz, &r.DetailsRu, &r.ApiEndpoint, &r.Method, &r.FunctionName, &r.StatusCode, &r.RequestPayload,
+			&r.EventType, &r.ResponseData, &r.MerchantApiKey, &r.GroupID, &r.UserID, &r.SessionID,
+			&r.ClientIP, &r.UserAgent, &r.RequestID, &r.Environment, &r.DurationMs)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,228 @@
+package logservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Config holds the gRPC log service settings.
+type Config struct {
+	Transport logger.Transport // Transport the accepted logs are published through.
+	Queue     string           // Queue the logs are published to.
+
+	// Authenticate returns the producer name of an API key, which is published as the log function name.
+	// A false result rejects the call with codes.Unauthenticated.
+	Authenticate func(apiKey string) (producer string, ok bool)
+
+	MaxBatchSize int // Maximum number of logs in a single EmitBatch call. Defaults to 500.
+}
+
+// Server implements logpb.LogServiceServer by publishing the received logs into the log queue.
+type Server struct {
+	logpb.UnimplementedLogServiceServer
+
+	config Config // Server settings with defaults applied.
+
+	mu      sync.Mutex               // Protects loggers.
+	loggers map[string]logger.Logger // Loggers per producer.
+}
+
+// New initializes and returns a new Server instance.
+// It returns an error if the configuration is incomplete.
+//
+// Usage:
+//
+//	srv, _ := logservice.New(logservice.Config{
+//		Transport:    logger.NewRabbitMQTransport(rmq),
+//		Queue:        "logs",
+//		Authenticate: func(key string) (string, bool) { name, ok := apiKeys[key]; return name, ok },
+//	})
+//	grpcServer := grpc.NewServer()
+//	logpb.RegisterLogServiceServer(grpcServer, srv)
+//	grpcServer.Serve(listener)
+func New(config Config) (*Server, error) {
+	if config.Transport == nil {
+		return nil, errors.New("transport is required")
+	}
+	if config.Queue == "" {
+		return nil, errors.New("queue is required")
+	}
+	if config.Authenticate == nil {
+		return nil, errors.New("authenticate function is required")
+	}
+	if config.MaxBatchSize <= 0 {
+		config.MaxBatchSize = 500
+	}
+
+	return &Server{
+		config:  config,
+		loggers: make(map[string]logger.Logger),
+	}, nil
+}
+
+// Emit publishes a single log.
+func (s *Server) Emit(ctx context.Context, req *logpb.EmitRequest) (*logpb.EmitResponse, error) {
+	l, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetEntry() == nil {
+		return nil, status.Error(codes.InvalidArgument, "entry is required")
+	}
+
+	if err := publish(l, req.GetEntry(), caller(ctx)); err != nil {
+		return nil, statusError(err)
+	}
+	return &logpb.EmitResponse{}, nil
+}
+
+// EmitBatch publishes several logs, reporting invalid ones individually.
+// A publishing failure aborts the batch with codes.Unavailable.
+func (s *Server) EmitBatch(ctx context.Context, req *logpb.EmitBatchRequest) (*logpb.EmitBatchResponse, error) {
+	l, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetEntries()) > s.config.MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch exceeds %d logs", s.config.MaxBatchSize)
+	}
+
+	resp := &logpb.EmitBatchResponse{}
+	defaults := caller(ctx)
+	for i, entry := range req.GetEntries() {
+		if err := publish(l, entry, defaults); err != nil {
+			if !errors.Is(err, logger.ErrInvalidLog) {
+				return nil, statusError(err)
+			}
+			resp.Errors = append(resp.Errors, &logpb.EntryError{Index: int32(i), Error: err.Error()})
+			continue
+		}
+		resp.Accepted++
+	}
+	return resp, nil
+}
+
+// authenticate returns the logger of the producer identified by the `x-api-key` metadata.
+func (s *Server) authenticate(ctx context.Context) (logger.Logger, error) {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-api-key"); len(values) > 0 {
+			key = values[0]
+		}
+	}
+
+	producer, ok := s.config.Authenticate(key)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.loggers[producer]; ok {
+		return l, nil
+	}
+
+	l, err := logger.NewLoggerWithTransport(s.config.Transport, s.config.Queue, producer, "", nil, nil)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	s.loggers[producer] = l
+	return l, nil
+}
+
+// caller returns the client IP, User-Agent and request ID of the gRPC call, the defaults of
+// the fields of its entries. The client IP is the first address of the `x-forwarded-for`
+// metadata entry, which can be forged by clients unless the proxy in front of the service
+// overwrites it, then the address of the peer.
+func caller(ctx context.Context) logger.LogRequest {
+	var log logger.LogRequest
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+
+	forwarded, _, _ := strings.Cut(first("x-forwarded-for"), ",")
+	log.ClientIP = strings.TrimSpace(forwarded)
+	if p, ok := peer.FromContext(ctx); ok && log.ClientIP == "" && p.Addr != nil {
+		log.ClientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(log.ClientIP); err == nil {
+			log.ClientIP = host
+		}
+	}
+	log.UserAgent = first("user-agent")
+	log.RequestID = first("x-request-id")
+	return log
+}
+
+// publish logs the entry with its level, taking the client IP, User-Agent and request ID the
+// entry leaves empty from the defaults.
+func publish(l logger.Logger, entry *logpb.LogEntry, defaults logger.LogRequest) error {
+	log := logger.LogRequest{
+		Errorcode:       logger.Errorcode(entry.GetErrorCode()),
+		ClientMessageUz: entry.GetClientMessageUz(),
+		ClientMessageRu: entry.GetClientMessageRu(),
+		ErrorMessage:    entry.GetErrorMessage(),
+		DetailsUz:       entry.GetDetailsUz(),
+		DetailsRu:       entry.GetDetailsRu(),
+		ApiEndpoint:     entry.GetApiEndpoint(),
+		Method:          entry.GetMethod(),
+		StatusCode:      int(entry.GetStatusCode()),
+		RequestPayload:  entry.GetRequestPayload(),
+		EventType:       logger.EventType(entry.GetEventType()),
+		ResponseData:    entry.GetResponseData(),
+		MerchantApiKey:  entry.GetMerchantApiKey(),
+		DurationMs:      entry.GetDurationMs(),
+		ClientIP:        entry.GetClientIp(),
+		UserAgent:       entry.GetUserAgent(),
+		UserID:          entry.GetUserId(),
+		RequestID:       entry.GetRequestId(),
+		GroupID:         entry.GetGroupId(),
+	}
+	if log.ClientIP == "" {
+		log.ClientIP = defaults.ClientIP
+	}
+	if log.UserAgent == "" {
+		log.UserAgent = defaults.UserAgent
+	}
+	if log.RequestID == "" {
+		log.RequestID = defaults.RequestID
+	}
+
+	switch entry.GetLevel() {
+	case logpb.Level_LEVEL_DEBUG:
+		return l.Debug(log)
+	case logpb.Level_LEVEL_UNSPECIFIED, logpb.Level_LEVEL_INFO:
+		return l.Info(log)
+	case logpb.Level_LEVEL_WARNING:
+		return l.Warn(log)
+	case logpb.Level_LEVEL_ERROR:
+		return l.Error(log)
+	case logpb.Level_LEVEL_CRITICAL:
+		return l.Critical(log)
+	default:
+		return fmt.Errorf("%w: unknown level %s", logger.ErrInvalidLog, entry.GetLevel())
+	}
+}
+
+// statusError converts a logger error into a gRPC status error.
+func statusError(err error) error {
+	if errors.Is(err, logger.ErrInvalidLog) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Errorf(codes.Unavailable, "failed to publish log: %s", err)
+}
@@ -0,0 +1,108 @@
+package logservice_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/consumers"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logpb"
+	"github.com/kupalovmuhammadjon/mybazar-logger/logservice"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// captureTransport records the bodies of the published messages.
+type captureTransport struct {
+	mu     sync.Mutex
+	bodies [][]byte
+}
+
+func (t *captureTransport) Declare(string) error { return nil }
+
+func (t *captureTransport) Publish(msg logger.Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bodies = append(t.bodies, append([]byte(nil), msg.Body...))
+	return nil
+}
+
+func (t *captureTransport) Close() error { return nil }
+
+func TestEmitCallerMetadata(t *testing.T) {
+	transport := &captureTransport{}
+	srv, err := logservice.New(logservice.Config{
+		Transport:    transport,
+		Queue:        "logs",
+		Authenticate: func(key string) (string, bool) { return "checkout", key == "secret" },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-api-key", "secret",
+		"user-agent", "checkout/1.2 grpc-go/1.70.0",
+		"x-request-id", "req-1",
+	))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 5), Port: 4242}})
+
+	entries := []*logpb.LogEntry{
+		{ErrorCode: int32(logger.InfoRequestProcessed), ClientMessageUz: "Tayyor", UserId: "42", GroupId: "import-1"},
+		{ErrorCode: int32(logger.InfoRequestProcessed), ClientMessageUz: "Tayyor", ClientIp: "203.0.113.7", UserAgent: "Mozilla/5.0", RequestId: "req-2"},
+	}
+	for _, entry := range entries {
+		if _, err := srv.Emit(ctx, &logpb.EmitRequest{Entry: entry}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []consumers.Record{
+		{ClientIP: "10.0.0.5", UserAgent: "checkout/1.2 grpc-go/1.70.0", RequestID: "req-1", UserID: "42", GroupID: "import-1"},
+		{ClientIP: "203.0.113.7", UserAgent: "Mozilla/5.0", RequestID: "req-2"},
+	}
+	if len(transport.bodies) != len(want) {
+		t.Fatalf("published %d logs, want %d", len(transport.bodies), len(want))
+	}
+	for i, body := range transport.bodies {
+		d, err := consumers.Decode(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := d.Record
+		if r.ClientIP != want[i].ClientIP || r.UserAgent != want[i].UserAgent || r.RequestID != want[i].RequestID ||
+			r.UserID != want[i].UserID || r.GroupID != want[i].GroupID {
+			t.Errorf("log %d: client %q, agent %q, request %q, user %q, group %q; want %+v",
+				i, r.ClientIP, r.UserAgent, r.RequestID, r.UserID, r.GroupID, want[i])
+		}
+	}
+}
+
+func TestEmitForwardedFor(t *testing.T) {
+	transport := &captureTransport{}
+	srv, err := logservice.New(logservice.Config{
+		Transport:    transport,
+		Queue:        "logs",
+		Authenticate: func(key string) (string, bool) { return "checkout", true },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.1, 10.0.0.1"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242}})
+	entry := &logpb.LogEntry{ErrorCode: int32(logger.InfoRequestProcessed), ClientMessageUz: "Tayyor"}
+	if _, err := srv.EmitBatch(ctx, &logpb.EmitBatchRequest{Entries: []*logpb.LogEntry{entry}}); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := consumers.Decode(transport.bodies[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Record.ClientIP != "198.51.100.1" {
+		t.Errorf("client IP %q, want the first forwarded address", d.Record.ClientIP)
+	}
+}
@@ -0,0 +1,183 @@
+package merchantlogger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
+)
+
+// LogQueue is the queue the logs of merchants are published to. Internal consumers subscribe to
+// it; merchants cannot choose another queue.
+const LogQueue = "merchant-logs"
+
+// OrderQueue returns the queue holding the order notifications of the merchant, consumed by
+// Client.Orders. Internal services publish the orders of the merchant to it.
+func OrderQueue(merchantID string) string {
+	return "merchant-orders." + merchantID
+}
+
+// Errors returned for logs the merchant is not allowed to publish.
+var (
+	ErrRateLimited    = errors.New("merchant log rate limit exceeded")
+	ErrRestrictedCode = errors.New("error code is not an integration error code")
+)
+
+// Config holds the settings of a Client.
+type Config struct {
+	MerchantID string // ID of the merchant, selecting its order queue. Required.
+	APIKey     string // API key of the merchant, stamped on its logs. Required.
+	RateLimit  int    // Maximum number of logs per minute; more are rejected with ErrRateLimited. Defaults to 60.
+	MaxLength  int    // Length in bytes messages and payloads are truncated to. Defaults to 4096.
+}
+
+// IntegrationError is an error of the merchant integration, the only kind of log merchants
+// may publish. Internal fields (function names, extra metadata, attachments) cannot be set.
+type IntegrationError struct {
+	Code       logger.Errorcode // Integration error code (5xxx). Defaults to logger.ErrAPIError.
+	Message    string           // Description of the error, truncated to Config.MaxLength.
+	Endpoint   string           // Endpoint of the merchant API that failed, truncated to Config.MaxLength.
+	Method     string           // HTTP method of the failed call.
+	StatusCode int              // Status code returned by the merchant API, zero if none.
+	Payload    string           // Optional request payload, truncated to Config.MaxLength.
+}
+
+// Client is the restricted logger handed to merchants: it publishes their integration errors
+// and delivers their order notifications, without access to the internal queues or to the
+// other operations of logger.Logger. It is safe for concurrent use.
+type Client struct {
+	config   Config            // Client settings with defaults applied.
+	rabbitMQ rabbitmq.RabbitMQ // Connection used to consume the orders.
+	log      logger.Logger     // Logger publishing to LogQueue.
+	mu       sync.Mutex        // Protects tokens and refilled.
+	tokens   float64           // Logs that may still be published, refilled over time up to RateLimit.
+	refilled time.Time         // Time tokens was last refilled.
+}
+
+// New returns a client publishing the logs of the merchant through the connection. The
+// connection is owned by the caller and left open by Close.
+//
+// Usage:
+//
+//	client, err := merchantlogger.New(rabbitMQ, merchantlogger.Config{MerchantID: "42", APIKey: apiKey})
+//	...
+//	err = client.LogIntegrationError(ctx, merchantlogger.IntegrationError{
+//		Code:       logger.ErrAPITimeout,
+//		Message:    "stock sync timed out",
+//		Endpoint:   "/api/stock",
+//		Method:     http.MethodPost,
+//		StatusCode: http.StatusGatewayTimeout,
+//	})
+func New(rabbitMQ rabbitmq.RabbitMQ, config Config) (*Client, error) {
+	if config.MerchantID == "" || config.APIKey == "" {
+		return nil, errors.New("merchant ID and API key are required")
+	}
+	if config.RateLimit <= 0 {
+		config.RateLimit = 60
+	}
+	if config.MaxLength <= 0 {
+		config.MaxLength = 4096
+	}
+
+	// The payload is optional for merchants, who may not be allowed to share it.
+	log, err := logger.NewLogger(rabbitMQ, LogQueue, "merchantlogger", "", nil, nil,
+		logger.WithValidation(logger.ValidationPolicy{PayloadLevels: []logger.Level{}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	return &Client{
+		config:   config,
+		rabbitMQ: rabbitMQ,
+		log:      log,
+		tokens:   float64(config.RateLimit),
+		refilled: time.Now(),
+	}, nil
+}
+
+// LogIntegrationError publishes the error with the API key of the merchant. It returns
+// ErrRestrictedCode for codes outside of the integration category and ErrRateLimited when the
+// merchant exceeds its rate limit.
+func (c *Client) LogIntegrationError(ctx context.Context, e IntegrationError) error {
+	if e.Code == 0 {
+		e.Code = logger.ErrAPIError
+	}
+	if e.Code.Category() != logger.CategoryIntegration {
+		return fmt.Errorf("%w: %d", ErrRestrictedCode, e.Code)
+	}
+	if !c.allow() {
+		return ErrRateLimited
+	}
+
+	message := e.Code.ClientMessage()
+	return c.log.ErrorContext(ctx, logger.LogRequest{
+		Errorcode:       e.Code,
+		ClientMessageUz: message.Uz,
+		ClientMessageRu: message.Ru,
+		ErrorMessage:    c.truncate(e.Message),
+		ApiEndpoint:     c.truncate(e.Endpoint),
+		Method:          e.Method,
+		StatusCode:      e.StatusCode,
+		RequestPayload:  c.truncate(e.Payload),
+		MerchantApiKey:  c.config.APIKey,
+	})
+}
+
+// Orders hands the order notifications of the merchant to the handler until the context is
+// done. Orders of other merchants are skipped. Orders the handler fails on are redelivered.
+func (c *Client) Orders(ctx context.Context, handler func(order logger.Order) error) error {
+	queue := OrderQueue(c.config.MerchantID)
+	if err := c.rabbitMQ.DeclareQueue(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare order queue: %w", err)
+	}
+	return c.rabbitMQ.ConsumeMessages(ctx, queue, 10, 0, 0, func(body []byte) error {
+		var order logger.Order
+		if err := json.Unmarshal(body, &order); err != nil {
+			// Malformed orders would be redelivered forever.
+			return nil
+		}
+		if order.MerchantId != c.config.MerchantID {
+			return nil
+		}
+		return handler(order)
+	})
+}
+
+// Close publishes the buffered logs, waiting at most until the context is done.
+func (c *Client) Close(ctx context.Context) error {
+	return c.log.Close(ctx)
+}
+
+// allow takes a token from the bucket of the merchant, refilled at RateLimit per minute.
+func (c *Client) allow() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	limit := float64(c.config.RateLimit)
+	c.tokens = min(limit, c.tokens+now.Sub(c.refilled).Minutes()*limit)
+	c.refilled = now
+	if c.tokens < 1 {
+		return false
+	}
+	c.tokens--
+	return true
+}
+
+// truncate shortens the value to MaxLength bytes without splitting a UTF-8 character.
+func (c *Client) truncate(value string) string {
+	if len(value) <= c.config.MaxLength {
+		return value
+	}
+	n := c.config.MaxLength
+	for n > 0 && !utf8.RuneStart(value[n]) {
+		n--
+	}
+	return value[:n]
+}
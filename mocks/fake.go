@@ -0,0 +1,265 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var _ rabbitmq.RabbitMQ = (*FakeRabbitMQ)(nil)
+
+// ErrFakeClosed is returned by a FakeRabbitMQ used after Close.
+var ErrFakeClosed = errors.New("RabbitMQ channel is closed")
+
+// fakeQueue is a queue of a FakeRabbitMQ.
+type fakeQueue struct {
+	durable    bool     // Durable flag of the declaration.
+	autoDelete bool     // Auto-delete flag of the declaration.
+	messages   [][]byte // Messages waiting to be consumed, oldest first.
+}
+
+// FakeRabbitMQ is a rabbitmq.RabbitMQ keeping queues and exchange bindings in memory, so
+// publishing and consuming code can be tested without a broker. It follows the broker and the
+// rabbitmq client where tests may notice:
+//
+//   - queues must be declared before being consumed, and redeclaring a queue with other
+//     flags fails;
+//   - messages published to the default exchange ("") go to the queue of the same name,
+//     messages published to another exchange go to every queue bound to it with Bind;
+//     messages without a destination queue are dropped and counted by Unroutable;
+//   - messages the handler fails on are requeued at the head of the queue and redelivered.
+//
+// Unlike MockRabbitMQ, which only checks expected calls, messages published to a FakeRabbitMQ can
+// be consumed. It is safe for concurrent use.
+//
+// Usage:
+//
+//	fake := mocks.NewFakeRabbitMQ()
+//	log, err := logger.NewLogger(fake, "logs", "SyncStock", "/internal/stock", nil, nil)
+//	...
+//	go fake.ConsumeMessages(ctx, "logs", 10, 0, 0, handler)
+type FakeRabbitMQ struct {
+	mu         sync.Mutex
+	queues     map[string]*fakeQueue // Declared queues by name.
+	bindings   map[string][]string   // Queues bound to each exchange.
+	unroutable int                   // Messages dropped for lack of a destination queue.
+	published  chan struct{}         // Closed and replaced when messages arrive, waking consumers.
+	closed     chan struct{}         // Closed by Close.
+}
+
+// NewFakeRabbitMQ returns an empty FakeRabbitMQ.
+func NewFakeRabbitMQ() *FakeRabbitMQ {
+	return &FakeRabbitMQ{
+		queues:    make(map[string]*fakeQueue),
+		bindings:  make(map[string][]string),
+		published: make(chan struct{}),
+		closed:    make(chan struct{}),
+	}
+}
+
+// DeclareQueue creates the queue if it does not exist. Redeclaring it with other durable or
+// auto-delete flags fails, like on the broker.
+func (f *FakeRabbitMQ) DeclareQueue(queueName string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.isClosed() {
+		return ErrFakeClosed
+	}
+
+	if q, ok := f.queues[queueName]; ok {
+		if q.durable != durable || q.autoDelete != autoDelete {
+			return fmt.Errorf("PRECONDITION_FAILED - inequivalent arg for queue %q", queueName)
+		}
+		return nil
+	}
+	f.queues[queueName] = &fakeQueue{durable: durable, autoDelete: autoDelete}
+	return nil
+}
+
+// Bind routes the messages published to the exchange to the queue, which must be declared.
+func (f *FakeRabbitMQ) Bind(exchangeName, queueName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.queues[queueName]; !ok {
+		return fmt.Errorf("queue %s not declared", queueName)
+	}
+	if !slices.Contains(f.bindings[exchangeName], queueName) {
+		f.bindings[exchangeName] = append(f.bindings[exchangeName], queueName)
+	}
+	return nil
+}
+
+// PublishMessage routes the message to its queues. Like the rabbitmq client, []byte and string
+// messages are published as is and other values as JSON.
+func (f *FakeRabbitMQ) PublishMessage(queueName, exchangeName string, message interface{}) error {
+	var body []byte
+	switch msg := message.(type) {
+	case []byte:
+		body = append([]byte(nil), msg...)
+	case string:
+		body = []byte(msg)
+	default:
+		var err error
+		if body, err = json.Marshal(msg); err != nil {
+			return err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.isClosed() {
+		return ErrFakeClosed
+	}
+
+	targets := f.bindings[exchangeName]
+	if exchangeName == "" {
+		targets = []string{queueName}
+	}
+	routed := false
+	for _, name := range targets {
+		if q, ok := f.queues[name]; ok {
+			q.messages = append(q.messages, body)
+			routed = true
+		}
+	}
+	if !routed {
+		f.unroutable++
+		return nil
+	}
+
+	close(f.published)
+	f.published = make(chan struct{})
+	return nil
+}
+
+// ConsumeMessages hands the messages of the queue to the handler until the context is done or
+// the fake is closed, requeueing those the handler fails on. prefetch, memoryLimit and pause
+// are ignored.
+func (f *FakeRabbitMQ) ConsumeMessages(ctx context.Context, queueName string, prefetch int, memoryLimit int, pause int, handler func([]byte) error) error {
+	f.mu.Lock()
+	if _, ok := f.queues[queueName]; !ok {
+		f.mu.Unlock()
+		return fmt.Errorf("queue %s not declared", queueName)
+	}
+	f.mu.Unlock()
+
+	for {
+		body, published, ok := f.next(queueName)
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-f.closed:
+				return nil
+			case <-published:
+			}
+			continue
+		}
+
+		if err := handler(bytes.TrimPrefix(body, []byte{0xEF, 0xBB, 0xBF})); err != nil {
+			f.requeue(queueName, body)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// next takes the oldest message of the queue. Without one, it returns the channel closed on
+// the next publish.
+func (f *FakeRabbitMQ) next(queueName string) ([]byte, <-chan struct{}, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q, ok := f.queues[queueName]
+	if !ok || len(q.messages) == 0 {
+		return nil, f.published, false
+	}
+	body := q.messages[0]
+	q.messages = q.messages[1:]
+	return body, nil, true
+}
+
+// requeue puts the message back at the head of the queue.
+func (f *FakeRabbitMQ) requeue(queueName string, body []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if q, ok := f.queues[queueName]; ok {
+		q.messages = append([][]byte{body}, q.messages...)
+	}
+}
+
+// Close stops the consumers; later calls fail with ErrFakeClosed. The queues stay inspectable.
+func (f *FakeRabbitMQ) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.isClosed() {
+		close(f.closed)
+	}
+	return nil
+}
+
+// isClosed reports whether Close was called. The caller must hold the lock.
+func (f *FakeRabbitMQ) isClosed() bool {
+	select {
+	case <-f.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Queues returns the names of the declared queues, sorted.
+func (f *FakeRabbitMQ) Queues() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	names := make([]string, 0, len(f.queues))
+	for name := range f.queues {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Messages returns the messages waiting in the queue, oldest first, without consuming them.
+func (f *FakeRabbitMQ) Messages(queueName string) [][]byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q, ok := f.queues[queueName]
+	if !ok {
+		return nil
+	}
+	return slices.Clone(q.messages)
+}
+
+// Purge removes the messages waiting in the queue and returns their number.
+func (f *FakeRabbitMQ) Purge(queueName string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q, ok := f.queues[queueName]
+	if !ok {
+		return 0
+	}
+	n := len(q.messages)
+	q.messages = nil
+	return n
+}
+
+// Unroutable returns the number of messages dropped because no queue matched their destination.
+func (f *FakeRabbitMQ) Unroutable() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.unroutable
+}
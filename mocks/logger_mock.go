@@ -0,0 +1,404 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kupalovmuhammadjon/mybazar-logger/logger (interfaces: Logger)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=logger_mock.go -package=mocks github.com/kupalovmuhammadjon/mybazar-logger/logger Logger
+//
+
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	logger "github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLogger is a mock of Logger interface.
+type MockLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoggerMockRecorder
+	isgomock struct{}
+}
+
+// MockLoggerMockRecorder is the mock recorder for MockLogger.
+type MockLoggerMockRecorder struct {
+	mock *MockLogger
+}
+
+// NewMockLogger creates a new mock instance.
+func NewMockLogger(ctrl *gomock.Controller) *MockLogger {
+	mock := &MockLogger{ctrl: ctrl}
+	mock.recorder = &MockLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogger) EXPECT() *MockLoggerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockLogger) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockLoggerMockRecorder) Close(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockLogger)(nil).Close), ctx)
+}
+
+// Critical mocks base method.
+func (m *MockLogger) Critical(log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Critical", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Critical indicates an expected call of Critical.
+func (mr *MockLoggerMockRecorder) Critical(log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Critical", reflect.TypeOf((*MockLogger)(nil).Critical), log)
+}
+
+// CriticalContext mocks base method.
+func (m *MockLogger) CriticalContext(ctx context.Context, log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CriticalContext", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CriticalContext indicates an expected call of CriticalContext.
+func (mr *MockLoggerMockRecorder) CriticalContext(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CriticalContext", reflect.TypeOf((*MockLogger)(nil).CriticalContext), ctx, log)
+}
+
+// Debug mocks base method.
+func (m *MockLogger) Debug(log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debug", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Debug indicates an expected call of Debug.
+func (mr *MockLoggerMockRecorder) Debug(log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockLogger)(nil).Debug), log)
+}
+
+// DebugContext mocks base method.
+func (m *MockLogger) DebugContext(ctx context.Context, log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DebugContext", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DebugContext indicates an expected call of DebugContext.
+func (mr *MockLoggerMockRecorder) DebugContext(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugContext", reflect.TypeOf((*MockLogger)(nil).DebugContext), ctx, log)
+}
+
+// Diagnostics mocks base method.
+func (m *MockLogger) Diagnostics() logger.Diagnostics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diagnostics")
+	ret0, _ := ret[0].(logger.Diagnostics)
+	return ret0
+}
+
+// Diagnostics indicates an expected call of Diagnostics.
+func (mr *MockLoggerMockRecorder) Diagnostics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diagnostics", reflect.TypeOf((*MockLogger)(nil).Diagnostics))
+}
+
+// DisableSink mocks base method.
+func (m *MockLogger) DisableSink(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableSink", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableSink indicates an expected call of DisableSink.
+func (mr *MockLoggerMockRecorder) DisableSink(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableSink", reflect.TypeOf((*MockLogger)(nil).DisableSink), name)
+}
+
+// Dropped mocks base method.
+func (m *MockLogger) Dropped() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dropped")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// Dropped indicates an expected call of Dropped.
+func (mr *MockLoggerMockRecorder) Dropped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dropped", reflect.TypeOf((*MockLogger)(nil).Dropped))
+}
+
+// EnableSink mocks base method.
+func (m *MockLogger) EnableSink(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableSink", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableSink indicates an expected call of EnableSink.
+func (mr *MockLoggerMockRecorder) EnableSink(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableSink", reflect.TypeOf((*MockLogger)(nil).EnableSink), name)
+}
+
+// Enabled mocks base method.
+func (m *MockLogger) Enabled(level logger.Level) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", level)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockLoggerMockRecorder) Enabled(level any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockLogger)(nil).Enabled), level)
+}
+
+// Error mocks base method.
+func (m *MockLogger) Error(log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockLoggerMockRecorder) Error(log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockLogger)(nil).Error), log)
+}
+
+// ErrorContext mocks base method.
+func (m *MockLogger) ErrorContext(ctx context.Context, log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ErrorContext", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ErrorContext indicates an expected call of ErrorContext.
+func (mr *MockLoggerMockRecorder) ErrorContext(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrorContext", reflect.TypeOf((*MockLogger)(nil).ErrorContext), ctx, log)
+}
+
+// Flush mocks base method.
+func (m *MockLogger) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockLoggerMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockLogger)(nil).Flush), ctx)
+}
+
+// Group mocks base method.
+func (m *MockLogger) Group() logger.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Group")
+	ret0, _ := ret[0].(logger.Logger)
+	return ret0
+}
+
+// Group indicates an expected call of Group.
+func (mr *MockLoggerMockRecorder) Group() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Group", reflect.TypeOf((*MockLogger)(nil).Group))
+}
+
+// Info mocks base method.
+func (m *MockLogger) Info(log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockLoggerMockRecorder) Info(log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockLogger)(nil).Info), log)
+}
+
+// InfoContext mocks base method.
+func (m *MockLogger) InfoContext(ctx context.Context, log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InfoContext", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InfoContext indicates an expected call of InfoContext.
+func (mr *MockLoggerMockRecorder) InfoContext(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InfoContext", reflect.TypeOf((*MockLogger)(nil).InfoContext), ctx, log)
+}
+
+// LogWithID mocks base method.
+func (m *MockLogger) LogWithID(level logger.Level, log logger.LogRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogWithID", level, log)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogWithID indicates an expected call of LogWithID.
+func (mr *MockLoggerMockRecorder) LogWithID(level, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogWithID", reflect.TypeOf((*MockLogger)(nil).LogWithID), level, log)
+}
+
+// MinLevel mocks base method.
+func (m *MockLogger) MinLevel() logger.Level {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinLevel")
+	ret0, _ := ret[0].(logger.Level)
+	return ret0
+}
+
+// MinLevel indicates an expected call of MinLevel.
+func (mr *MockLoggerMockRecorder) MinLevel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinLevel", reflect.TypeOf((*MockLogger)(nil).MinLevel))
+}
+
+// OrderNotification mocks base method.
+func (m *MockLogger) OrderNotification(order logger.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderNotification", order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrderNotification indicates an expected call of OrderNotification.
+func (mr *MockLoggerMockRecorder) OrderNotification(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderNotification", reflect.TypeOf((*MockLogger)(nil).OrderNotification), order)
+}
+
+// Ping mocks base method.
+func (m *MockLogger) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockLoggerMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockLogger)(nil).Ping), ctx)
+}
+
+// SendOrderToBitrix mocks base method.
+func (m *MockLogger) SendOrderToBitrix(order logger.BitrixOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendOrderToBitrix", order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendOrderToBitrix indicates an expected call of SendOrderToBitrix.
+func (mr *MockLoggerMockRecorder) SendOrderToBitrix(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderToBitrix", reflect.TypeOf((*MockLogger)(nil).SendOrderToBitrix), order)
+}
+
+// SetMinLevel mocks base method.
+func (m *MockLogger) SetMinLevel(level logger.Level) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMinLevel", level)
+}
+
+// SetMinLevel indicates an expected call of SetMinLevel.
+func (mr *MockLoggerMockRecorder) SetMinLevel(level any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinLevel", reflect.TypeOf((*MockLogger)(nil).SetMinLevel), level)
+}
+
+// SinkEnabled mocks base method.
+func (m *MockLogger) SinkEnabled(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SinkEnabled", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SinkEnabled indicates an expected call of SinkEnabled.
+func (mr *MockLoggerMockRecorder) SinkEnabled(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SinkEnabled", reflect.TypeOf((*MockLogger)(nil).SinkEnabled), name)
+}
+
+// Stats mocks base method.
+func (m *MockLogger) Stats() logger.Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(logger.Stats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockLoggerMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockLogger)(nil).Stats))
+}
+
+// Warn mocks base method.
+func (m *MockLogger) Warn(log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Warn", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Warn indicates an expected call of Warn.
+func (mr *MockLoggerMockRecorder) Warn(log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLogger)(nil).Warn), log)
+}
+
+// WarnContext mocks base method.
+func (m *MockLogger) WarnContext(ctx context.Context, log logger.LogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarnContext", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WarnContext indicates an expected call of WarnContext.
+func (mr *MockLoggerMockRecorder) WarnContext(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarnContext", reflect.TypeOf((*MockLogger)(nil).WarnContext), ctx, log)
+}
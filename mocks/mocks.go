@@ -0,0 +1,28 @@
+// Package mocks provides test doubles of logger.Logger and rabbitmq.RabbitMQ, so services stop
+// maintaining their own and breaking when the interfaces grow:
+//
+//   - MockLogger and MockRabbitMQ are gomock mocks generated by mockgen from the interfaces;
+//     expectations are set with EXPECT and checked by the gomock.Controller;
+//   - FakeRabbitMQ keeps queues in memory, so published messages can be consumed.
+//
+// Usage:
+//
+//	ctrl := gomock.NewController(t)
+//	log := mocks.NewMockLogger(ctrl)
+//	log.EXPECT().Error(gomock.Any()).Return(nil)
+package mocks
+
+import (
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	rabbitmq "github.com/kupalovmuhammadjon/rabbitmq-go"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -write_package_comment=false -destination=logger_mock.go -package=mocks github.com/kupalovmuhammadjon/mybazar-logger/logger Logger
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -write_package_comment=false -destination=rabbitmq_mock.go -package=mocks github.com/kupalovmuhammadjon/rabbitmq-go RabbitMQ
+
+// The mocks are checked against the interfaces here, so a growing interface fails the build of
+// this package until the mocks are regenerated.
+var (
+	_ logger.Logger     = (*MockLogger)(nil)
+	_ rabbitmq.RabbitMQ = (*MockRabbitMQ)(nil)
+)
@@ -0,0 +1,52 @@
+package mocks_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/kupalovmuhammadjon/mybazar-logger/mocks"
+)
+
+func TestMockRabbitMQ(t *testing.T) {
+	errBroker := errors.New("broker down")
+	ctrl := gomock.NewController(t)
+	rabbitMQ := mocks.NewMockRabbitMQ(ctrl)
+	rabbitMQ.EXPECT().DeclareQueue("logs", true, true, false, false, gomock.Any()).Return(nil)
+	rabbitMQ.EXPECT().PublishMessage("logs", "", gomock.Any()).Return(errBroker)
+
+	log, err := logger.NewLogger(rabbitMQ, "logs", "SyncStock", "/internal/stock", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = log.Info(logger.LogRequest{Errorcode: logger.InfoRequestProcessed, ClientMessageUz: "Tayyor"})
+	if !errors.Is(err, errBroker) {
+		t.Errorf("Info returned %v, want the error of PublishMessage", err)
+	}
+}
+
+func TestMockLogger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	log := mocks.NewMockLogger(ctrl)
+	log.EXPECT().Enabled(gomock.Any()).DoAndReturn(func(level logger.Level) bool { return level >= logger.LevelWarn }).Times(2)
+	log.EXPECT().Error(gomock.Cond(func(req logger.LogRequest) bool { return req.Errorcode == logger.ErrInvalidData })).Return(nil)
+
+	if log.Enabled(logger.LevelInfo) || !log.Enabled(logger.LevelError) {
+		t.Error("Enabled does not follow the expectation")
+	}
+	if err := log.Error(logger.LogRequest{Errorcode: logger.ErrInvalidData}); err != nil {
+		t.Errorf("Error returned %v", err)
+	}
+}
+
+func TestRabbitMQRejectsBinaryEncoding(t *testing.T) {
+	_, err := logger.NewLogger(mocks.NewFakeRabbitMQ(), "logs", "SyncStock", "/internal/stock", nil, nil,
+		logger.WithEncoding(logger.EncodingMsgPack),
+	)
+	if !errors.Is(err, logger.ErrUnsupportedEncoding) {
+		t.Errorf("NewLogger returned %v, want ErrUnsupportedEncoding", err)
+	}
+}
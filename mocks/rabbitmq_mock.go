@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kupalovmuhammadjon/rabbitmq-go (interfaces: RabbitMQ)
+//
+// Generated by this command:
+//
+//	mockgen -write_package_comment=false -destination=rabbitmq_mock.go -package=mocks github.com/kupalovmuhammadjon/rabbitmq-go RabbitMQ
+//
+
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRabbitMQ is a mock of RabbitMQ interface.
+type MockRabbitMQ struct {
+	ctrl     *gomock.Controller
+	recorder *MockRabbitMQMockRecorder
+	isgomock struct{}
+}
+
+// MockRabbitMQMockRecorder is the mock recorder for MockRabbitMQ.
+type MockRabbitMQMockRecorder struct {
+	mock *MockRabbitMQ
+}
+
+// NewMockRabbitMQ creates a new mock instance.
+func NewMockRabbitMQ(ctrl *gomock.Controller) *MockRabbitMQ {
+	mock := &MockRabbitMQ{ctrl: ctrl}
+	mock.recorder = &MockRabbitMQMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRabbitMQ) EXPECT() *MockRabbitMQMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRabbitMQ) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRabbitMQMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRabbitMQ)(nil).Close))
+}
+
+// ConsumeMessages mocks base method.
+func (m *MockRabbitMQ) ConsumeMessages(ctx context.Context, queueName string, prefetch, memoryLimit, pause int, handler func([]byte) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeMessages", ctx, queueName, prefetch, memoryLimit, pause, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeMessages indicates an expected call of ConsumeMessages.
+func (mr *MockRabbitMQMockRecorder) ConsumeMessages(ctx, queueName, prefetch, memoryLimit, pause, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeMessages", reflect.TypeOf((*MockRabbitMQ)(nil).ConsumeMessages), ctx, queueName, prefetch, memoryLimit, pause, handler)
+}
+
+// DeclareQueue mocks base method.
+func (m *MockRabbitMQ) DeclareQueue(queueName string, durable, autoDelete, exclusive, noWait bool, args amqp091.Table) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclareQueue", queueName, durable, autoDelete, exclusive, noWait, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclareQueue indicates an expected call of DeclareQueue.
+func (mr *MockRabbitMQMockRecorder) DeclareQueue(queueName, durable, autoDelete, exclusive, noWait, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclareQueue", reflect.TypeOf((*MockRabbitMQ)(nil).DeclareQueue), queueName, durable, autoDelete, exclusive, noWait, args)
+}
+
+// PublishMessage mocks base method.
+func (m *MockRabbitMQ) PublishMessage(queueName, exchangeName string, message any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMessage", queueName, exchangeName, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishMessage indicates an expected call of PublishMessage.
+func (mr *MockRabbitMQMockRecorder) PublishMessage(queueName, exchangeName, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockRabbitMQ)(nil).PublishMessage), queueName, exchangeName, message)
+}
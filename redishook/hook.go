@@ -0,0 +1,135 @@
+package redishook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// Hook is a go-redis hook that reports cache failures and slow commands to the logger.
+// Failed commands are logged as errors with `ErrCacheSyncFailed`, commands slower than
+// the configured threshold are logged as warnings with `WarnHighResponseTime`.
+type Hook struct {
+	logger        logger.Logger // Logger used to publish cache logs.
+	slowThreshold time.Duration // Commands taking longer than this are reported; zero disables latency reporting.
+}
+
+// New initializes and returns a new Hook instance.
+// Parameters:
+// - l: Logger used to publish cache logs.
+// - slowThreshold: Latency above which a command is reported as slow (zero disables it).
+//
+// Usage:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	rdb.AddHook(redishook.New(log, 200*time.Millisecond))
+func New(l logger.Logger, slowThreshold time.Duration) *Hook {
+	return &Hook{
+		logger:        l,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// DialHook reports failed connection attempts to the Redis server.
+func (h *Hook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			h.logFailure("dial", map[string]string{"network": network, "addr": addr}, err)
+		}
+		return conn, err
+	}
+}
+
+// ProcessHook reports failed and slow single commands.
+func (h *Hook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		elapsed := time.Since(start)
+
+		if isFailure(cmd.Err()) {
+			h.logFailure(cmd.FullName(), commandPayload(cmd), cmd.Err())
+		} else if h.isSlow(elapsed) {
+			h.logSlow(cmd.FullName(), commandPayload(cmd), elapsed)
+		}
+		return err
+	}
+}
+
+// ProcessPipelineHook reports failed commands and slow pipelines.
+func (h *Hook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		elapsed := time.Since(start)
+
+		for _, cmd := range cmds {
+			if isFailure(cmd.Err()) {
+				h.logFailure(cmd.FullName(), commandPayload(cmd), cmd.Err())
+			}
+		}
+
+		if h.isSlow(elapsed) {
+			payload := make([]map[string]any, 0, len(cmds))
+			for _, cmd := range cmds {
+				payload = append(payload, commandPayload(cmd))
+			}
+			h.logSlow("pipeline", payload, elapsed)
+		}
+		return err
+	}
+}
+
+// logFailure publishes an error log for a failed cache operation.
+// Errors returned by the logger are ignored, since a logging failure must not break the cache call.
+func (h *Hook) logFailure(name string, payload any, err error) {
+	_ = h.logger.Error(logger.LogRequest{
+		Errorcode:       logger.ErrCacheSyncFailed,
+		ClientMessageUz: "Kesh bilan ishlashda xatolik yuz berdi",
+		ClientMessageRu: "Ошибка при работе с кэшем",
+		ErrorMessage:    fmt.Sprintf("redis %s failed: %s", name, err),
+		StatusCode:      500,
+		RequestPayload:  payload,
+		EventType:       logger.EventType("redis_" + name),
+	})
+}
+
+// logSlow publishes a warning log for a cache operation that exceeded the latency threshold.
+func (h *Hook) logSlow(name string, payload any, elapsed time.Duration) {
+	_ = h.logger.Warn(logger.LogRequest{
+		Errorcode:       logger.WarnHighResponseTime,
+		ClientMessageUz: "Keshning javob vaqti yuqori",
+		ClientMessageRu: "Высокое время ответа кэша",
+		ErrorMessage:    fmt.Sprintf("redis %s took %s (threshold %s)", name, elapsed, h.slowThreshold),
+		RequestPayload:  payload,
+		EventType:       logger.EventType("redis_" + name),
+		DurationMs:      elapsed.Milliseconds(),
+	})
+}
+
+// isSlow reports whether the elapsed time exceeds the configured threshold.
+func (h *Hook) isSlow(elapsed time.Duration) bool {
+	return h.slowThreshold > 0 && elapsed > h.slowThreshold
+}
+
+// isFailure reports whether the command error is a real failure.
+// `redis.Nil` only signals a cache miss and is not logged.
+func isFailure(err error) bool {
+	return err != nil && !errors.Is(err, redis.Nil)
+}
+
+// commandPayload builds the request payload for a command. Only the command name and key
+// are recorded, so cached values never end up in the log stream.
+func commandPayload(cmd redis.Cmder) map[string]any {
+	payload := map[string]any{"command": cmd.FullName()}
+	if args := cmd.Args(); len(args) > 1 {
+		payload["key"] = fmt.Sprint(args[1])
+	}
+	return payload
+}
@@ -0,0 +1,336 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Kind is the type of message found in a dead-letter queue.
+type Kind string
+
+// Message kinds published by the logger.
+const (
+	KindLog         Kind = "log"
+	KindOrder       Kind = "order"
+	KindBitrixOrder Kind = "bitrix_order"
+	KindUnknown     Kind = "unknown"
+)
+
+// Message is a single dead-lettered message.
+type Message struct {
+	Index         int        // Position of the message in the dead-letter queue, starting at 1.
+	Kind          Kind       // Detected message kind.
+	OriginalQueue string     // Queue the message was dead-lettered from, taken from the x-death header.
+	Reason        string     // Dead-letter reason (rejected, expired, maxlen, ...).
+	Errorcode     int        // Error code for log messages.
+	Body          []byte     // Message body; mutation hooks may change it.
+	Headers       amqp.Table // Message headers; mutation hooks may change them.
+}
+
+// Summary describes the content of a dead-letter queue.
+type Summary struct {
+	Total    int            // Number of messages inspected.
+	ByKind   map[Kind]int   // Number of messages per kind.
+	ByQueue  map[string]int // Number of messages per original queue.
+	ByReason map[string]int // Number of messages per dead-letter reason.
+	ByCode   map[int]int    // Number of log messages per error code.
+}
+
+// Options configures a replay.
+type Options struct {
+	URL    string // AMQP connection URL.
+	DLQ    string // Dead-letter queue to read from.
+	Target string // Queue to publish to; defaults to each message's original queue.
+	Limit  int    // Maximum number of messages read from the dead-letter queue; zero reads all.
+
+	// Select chooses the messages to replay; nil replays every message.
+	Select func(Message) bool
+
+	// Mutate is called before a message is re-published and may change its body or headers.
+	// Returning an error leaves the message in the dead-letter queue.
+	Mutate func(*Message) error
+
+	// DryRun only reports what would be replayed, leaving the dead-letter queue untouched.
+	DryRun bool
+}
+
+// Result describes the outcome of a replay.
+type Result struct {
+	Replayed int     // Number of messages re-published and removed from the dead-letter queue.
+	Skipped  int     // Number of messages not selected.
+	Failed   []error // Errors of messages that stayed in the dead-letter queue.
+}
+
+// Inspect reads up to limit messages (zero reads all) from the dead-letter queue without removing them.
+func Inspect(ctx context.Context, url, dlq string, limit int) (Summary, []Message, error) {
+	var messages []Message
+	err := walk(ctx, url, dlq, limit, func(_ *session, m Message, _ amqp.Delivery) (bool, error) {
+		messages = append(messages, m)
+		return false, nil
+	})
+	if err != nil {
+		return Summary{}, nil, err
+	}
+	return Summarize(messages), messages, nil
+}
+
+// Summarize counts the messages by kind, original queue, reason and error code.
+func Summarize(messages []Message) Summary {
+	s := Summary{
+		Total:    len(messages),
+		ByKind:   make(map[Kind]int),
+		ByQueue:  make(map[string]int),
+		ByReason: make(map[string]int),
+		ByCode:   make(map[int]int),
+	}
+	for _, m := range messages {
+		s.ByKind[m.Kind]++
+		s.ByQueue[m.OriginalQueue]++
+		s.ByReason[m.Reason]++
+		if m.Kind == KindLog {
+			s.ByCode[m.Errorcode]++
+		}
+	}
+	return s
+}
+
+// String renders the summary as human readable text.
+func (s Summary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d messages\n", s.Total)
+	writeCounts(&b, "kind", s.ByKind)
+	writeCounts(&b, "original queue", s.ByQueue)
+	writeCounts(&b, "reason", s.ByReason)
+	writeCounts(&b, "error code", s.ByCode)
+	return b.String()
+}
+
+// writeCounts appends sorted counts under a heading.
+func writeCounts[K comparable](b *strings.Builder, heading string, counts map[K]int) {
+	if len(counts) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(counts))
+	values := make(map[string]int, len(counts))
+	for k, v := range counts {
+		key := fmt.Sprint(k)
+		keys = append(keys, key)
+		values[key] = v
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(b, "by %s:\n", heading)
+	for _, key := range keys {
+		fmt.Fprintf(b, "  %-30s %d\n", key, values[key])
+	}
+}
+
+// Replay re-publishes the selected dead-lettered messages to their original (or the target) queue.
+// A message is removed from the dead-letter queue only after the broker confirmed its re-publication.
+func Replay(ctx context.Context, opts Options) (Result, error) {
+	var result Result
+	err := walk(ctx, opts.URL, opts.DLQ, opts.Limit, func(s *session, m Message, d amqp.Delivery) (bool, error) {
+		if opts.Select != nil && !opts.Select(m) {
+			result.Skipped++
+			return false, nil
+		}
+
+		if opts.Mutate != nil {
+			if err := opts.Mutate(&m); err != nil {
+				result.Failed = append(result.Failed, fmt.Errorf("message %d: mutation failed: %w", m.Index, err))
+				return false, nil
+			}
+		}
+
+		target := opts.Target
+		if target == "" {
+			target = m.OriginalQueue
+		}
+		if target == "" {
+			result.Failed = append(result.Failed, fmt.Errorf("message %d: original queue unknown, set a target", m.Index))
+			return false, nil
+		}
+
+		if opts.DryRun {
+			result.Replayed++
+			return false, nil
+		}
+
+		if err := s.publish(ctx, target, m, d); err != nil {
+			result.Failed = append(result.Failed, fmt.Errorf("message %d: %w", m.Index, err))
+			return false, nil
+		}
+		result.Replayed++
+		return true, nil
+	})
+	return result, err
+}
+
+// session is the broker connection used while walking a dead-letter queue.
+type session struct {
+	conn   *amqp.Connection // Broker connection.
+	ch     *amqp.Channel    // Channel in confirm mode used for gets and publishes.
+	queues map[string]bool  // Target queues known to exist.
+}
+
+// walk gets messages from the queue one by one, keeping them unacknowledged so each message is seen once.
+// Messages for which visit returns true are acknowledged (removed), all others are requeued at the end.
+func walk(ctx context.Context, url, queue string, limit int, visit func(*session, Message, amqp.Delivery) (bool, error)) error {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.Confirm(false); err != nil {
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	s := &session{conn: conn, ch: ch, queues: make(map[string]bool)}
+
+	var pending []amqp.Delivery
+	defer func() {
+		for _, d := range pending {
+			d.Nack(false, true)
+		}
+	}()
+
+	for index := 1; limit <= 0 || index <= limit; index++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		d, ok, err := ch.Get(queue, false)
+		if err != nil {
+			return fmt.Errorf("failed to get message: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+
+		remove, err := visit(s, parse(index, d), d)
+		if err != nil {
+			pending = append(pending, d)
+			return err
+		}
+		if remove {
+			if err := d.Ack(false); err != nil {
+				return fmt.Errorf("failed to acknowledge message %d: %w", index, err)
+			}
+		} else {
+			pending = append(pending, d)
+		}
+	}
+	return nil
+}
+
+// publish re-publishes the message to the target queue and waits for the broker confirmation.
+func (s *session) publish(ctx context.Context, target string, m Message, d amqp.Delivery) error {
+	// The broker confirms messages even when no queue receives them, so check the target first.
+	if err := s.checkQueue(target); err != nil {
+		return err
+	}
+
+	headers := amqp.Table{}
+	for key, value := range m.Headers {
+		if !strings.HasPrefix(key, "x-death") && !strings.HasPrefix(key, "x-first-death") && !strings.HasPrefix(key, "x-last-death") {
+			headers[key] = value
+		}
+	}
+	headers["x-replayed-from"] = d.RoutingKey
+
+	confirmation, err := s.ch.PublishWithDeferredConfirmWithContext(ctx, "", target, false, false, amqp.Publishing{
+		ContentType:  d.ContentType,
+		DeliveryMode: d.DeliveryMode,
+		MessageId:    d.MessageId,
+		Timestamp:    d.Timestamp,
+		Headers:      headers,
+		Body:         m.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", target, err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errors.New("broker rejected the message")
+	}
+	return nil
+}
+
+// checkQueue verifies that the queue exists. A separate channel is used because a failed
+// passive declaration closes the channel it was sent on.
+func (s *session) checkQueue(name string) error {
+	if s.queues[name] {
+		return nil
+	}
+
+	ch, err := s.conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if _, err := ch.QueueDeclarePassive(name, false, false, false, false, nil); err != nil {
+		return fmt.Errorf("target queue %s does not exist: %w", name, err)
+	}
+	s.queues[name] = true
+	return nil
+}
+
+// parse extracts the kind and dead-letter details of a delivery.
+func parse(index int, d amqp.Delivery) Message {
+	m := Message{
+		Index:   index,
+		Kind:    KindUnknown,
+		Body:    d.Body,
+		Headers: d.Headers,
+	}
+
+	if queue, ok := d.Headers["x-first-death-queue"].(string); ok {
+		m.OriginalQueue = queue
+	}
+	if reason, ok := d.Headers["x-first-death-reason"].(string); ok {
+		m.Reason = reason
+	}
+	if deaths, ok := d.Headers["x-death"].([]any); ok && len(deaths) > 0 {
+		if death, ok := deaths[0].(amqp.Table); ok {
+			if queue, ok := death["queue"].(string); ok && m.OriginalQueue == "" {
+				m.OriginalQueue = queue
+			}
+			if reason, ok := death["reason"].(string); ok && m.Reason == "" {
+				m.Reason = reason
+			}
+		}
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(d.Body, &fields); err != nil {
+		return m
+	}
+	switch {
+	case fields["error_level"] != nil:
+		m.Kind = KindLog
+		json.Unmarshal(fields["error_code"], &m.Errorcode)
+	case fields["order_text"] != nil:
+		m.Kind = KindOrder
+	case fields["order_ids"] != nil:
+		m.Kind = KindBitrixOrder
+	}
+	return m
+}
@@ -0,0 +1,39 @@
+package s3offload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/kupalovmuhammadjon/mybazar-logger/logger"
+	"github.com/minio/minio-go/v7"
+)
+
+// store is the logger.BlobStore implementation backed by S3-compatible storage.
+type store struct {
+	client *minio.Client // S3/MinIO client.
+	bucket string        // Bucket the payloads are written to.
+}
+
+// New returns a BlobStore writing offloaded payloads to the bucket.
+// Set a lifecycle rule on the bucket matching the retention of the logs, so payloads expire with them.
+//
+// Usage:
+//
+//	client, _ := minio.New("s3.amazonaws.com", &minio.Options{Creds: credentials.NewStaticV4(key, secret, ""), Secure: true})
+//	log, err := logger.NewLogger(rabbitMQ, "logs", "CreateOrder", "/api/v1/orders", nil, nil,
+//		logger.WithPayloadOffload(logger.OffloadConfig{Store: s3offload.New(client, "mybazar-log-payloads")}),
+//	)
+func New(client *minio.Client, bucket string) logger.BlobStore {
+	return &store{client: client, bucket: bucket}
+}
+
+// Put uploads the payload as a single object.
+func (s *store) Put(ctx context.Context, key string, data []byte) (string, error) {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload payload: %w", err)
+	}
+	return s.bucket, nil
+}